@@ -4,6 +4,8 @@ import (
     "k8s.io/apimachinery/pkg/runtime"
 
     kafkaconnectv1alpha1 "github.com/crossplane/provider-kafkaconnect/apis/kafkaconnect/v1alpha1"
+    nskafkaconnectv1alpha1 "github.com/crossplane/provider-kafkaconnect/apis/namespaced/kafkaconnect/v1alpha1"
+    nsv1alpha1 "github.com/crossplane/provider-kafkaconnect/apis/namespaced/v1alpha1"
     v1alpha1 "github.com/crossplane/provider-kafkaconnect/apis/v1alpha1"
 )
 
@@ -12,6 +14,8 @@ func init() {
     AddToSchemes = append(AddToSchemes,
         v1alpha1.SchemeBuilder.AddToScheme,
         kafkaconnectv1alpha1.SchemeBuilder.AddToScheme,
+        nsv1alpha1.SchemeBuilder.AddToScheme,
+        nskafkaconnectv1alpha1.SchemeBuilder.AddToScheme,
     )
 }
 
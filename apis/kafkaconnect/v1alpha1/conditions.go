@@ -0,0 +1,68 @@
+/*
+Copyright 2025 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+)
+
+// Reasons a Connector is not ready or not synced.
+const (
+	ReasonClusterMismatch    xpv1.ConditionReason = "ClusterMismatch"
+	ReasonClusterUnavailable xpv1.ConditionReason = "ClusterUnavailable"
+)
+
+// ClusterMismatch returns a condition that indicates a Connector is not
+// reconciled because its ProviderConfig points at an unexpected Kafka Connect
+// or Kafka cluster.
+func ClusterMismatch(err error) xpv1.Condition {
+	return xpv1.Condition{
+		Type:               xpv1.TypeReady,
+		Status:             corev1.ConditionFalse,
+		LastTransitionTime: metav1.Now(),
+		Reason:             ReasonClusterMismatch,
+		Message:            err.Error(),
+	}
+}
+
+// ClusterUnavailable returns a condition that indicates a Connector is not
+// reconciled because the circuit breaker of its Kafka Connect cluster is open.
+func ClusterUnavailable(err error) xpv1.Condition {
+	return xpv1.Condition{
+		Type:               xpv1.TypeReady,
+		Status:             corev1.ConditionFalse,
+		LastTransitionTime: metav1.Now(),
+		Reason:             ReasonClusterUnavailable,
+		Message:            err.Error(),
+	}
+}
+
+// ReconcileClusterUnavailable returns a Synced condition that indicates a
+// Connector could not be reconciled because the circuit breaker of its Kafka
+// Connect cluster is open.
+func ReconcileClusterUnavailable(err error) xpv1.Condition {
+	return xpv1.Condition{
+		Type:               xpv1.TypeSynced,
+		Status:             corev1.ConditionFalse,
+		LastTransitionTime: metav1.Now(),
+		Reason:             ReasonClusterUnavailable,
+		Message:            err.Error(),
+	}
+}
@@ -3,6 +3,7 @@ package v1alpha1
 import (
     "reflect"
 
+    extv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
     metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
     "k8s.io/apimachinery/pkg/runtime/schema"
 
@@ -10,8 +11,11 @@ import (
 )
 
 // ConnectorParameters are the configurable fields of a Connector.
+// +kubebuilder:validation:XValidation:rule="!has(self.transforms) || self.transforms.all(t, !has(t.predicate) || (has(self.predicates) && self.predicates.exists(p, p.name == t.predicate)))",message="transforms may only reference predicates that are configured"
 type ConnectorParameters struct {
-    // Name of the connector
+    // Name of the connector. It cannot be changed once the connector is
+    // created.
+    // +kubebuilder:validation:XValidation:rule="self == oldSelf",message="name is immutable"
     Name string `json:"name"`
     
     // ConnectorClass is the Java class for the connector
@@ -20,17 +24,296 @@ type ConnectorParameters struct {
     
     // TasksMax is the maximum number of tasks
     // +kubebuilder:default=1
+    // +kubebuilder:validation:Minimum=1
     TasksMax int `json:"tasksMax,omitempty"`
     
-    // Config contains connector-specific configuration
-    // +kubebuilder:pruning:PreserveUnknownFields
-    Config map[string]string `json:"config"`
+    // Config contains connector-specific configuration. Values may be
+    // strings, numbers, booleans, or lists of them. They are passed to Kafka
+    // Connect as strings: numbers in plain decimal notation, booleans as true
+    // or false, and lists as their elements joined with commas. Elements of
+    // lists may not contain commas. The name, class and maximum number of
+    // tasks of the connector are set by their own fields, and may not be set
+    // here. Invalid config is reported when the Connector is reconciled,
+    // since values that have no schema cannot be validated by CEL rules.
+    Config map[string]extv1.JSON `json:"config"`
     
-    // KafkaConnectURL is the URL of the Kafka Connect instance
+    // Transforms are the single message transforms applied to the records of
+    // the connector, in order. They are rendered into the transforms config
+    // keys, which Config may not set if any transforms are configured.
     // +optional
+    // +listType=map
+    // +listMapKey=name
+    // +kubebuilder:validation:MaxItems=64
+    Transforms []Transform `json:"transforms,omitempty"`
+    
+    // Predicates transforms may be conditionally applied with. They are
+    // rendered into the predicates config keys, which Config may not set if
+    // any predicates are configured.
+    // +optional
+    // +listType=map
+    // +listMapKey=name
+    // +kubebuilder:validation:MaxItems=64
+    Predicates []Predicate `json:"predicates,omitempty"`
+    
+    // ErrorHandling configures how the connector handles records it fails to
+    // convert or transform, or, for sink connectors, to write. It is rendered
+    // into the errors config keys, which Config may not set if it is
+    // configured.
+    // +optional
+    ErrorHandling *ErrorHandling `json:"errorHandling,omitempty"`
+    
+    // Converters of the connector's keys, values and headers. Each converter
+    // configured is rendered into its <key|value|header>.converter config
+    // keys, which Config may not set then.
+    // +optional
+    Converters *Converters `json:"converters,omitempty"`
+    
+    // TopicCreation configures the topics a source connector creates for the
+    // records it produces, if topic creation is enabled on the workers. It is
+    // rendered into the topic.creation config keys, which Config may not set
+    // if it is configured.
+    // +optional
+    TopicCreation *TopicCreation `json:"topicCreation,omitempty"`
+    
+    // AutoRestart restarts the connector and its tasks when they fail.
+    // +optional
+    AutoRestart *AutoRestart `json:"autoRestart,omitempty"`
+    
+    // KafkaConnectURL is the URL of the Kafka Connect instance. It overrides
+    // the URLs of the ProviderConfig, and must be on one of the hosts the
+    // ProviderConfig allows.
+    // +optional
+    // +kubebuilder:validation:XValidation:rule="isURL(self) && url(self).getScheme() in ['http', 'https']",message="kafkaConnectUrl must be an http or https URL"
     KafkaConnectURL string `json:"kafkaConnectUrl,omitempty"`
 }
 
+// AutoRestart configures the automated restarts of a failed connector and its
+// failed tasks.
+type AutoRestart struct {
+    // Enabled restarts the connector and its tasks when they fail.
+    Enabled bool `json:"enabled"`
+    
+    // MinInterval is the minimum time between two restarts, so that a
+    // connector that keeps failing is not restarted on every reconcile.
+    // +kubebuilder:default="5m"
+    // +optional
+    MinInterval *metav1.Duration `json:"minInterval,omitempty"`
+}
+
+// A Transform is a single message transform (SMT) applied to the records of a
+// connector.
+// +kubebuilder:validation:XValidation:rule="!has(self.negate) || !self.negate || has(self.predicate)",message="negate requires a predicate"
+type Transform struct {
+    // Name of the transform, unique within the connector.
+    // +kubebuilder:validation:Pattern=`^[A-Za-z0-9_-]+$`
+    // +kubebuilder:validation:MaxLength=64
+    Name string `json:"name"`
+    
+    // Type is the Java class of the transform.
+    // +kubebuilder:validation:MinLength=1
+    Type string `json:"type"`
+    
+    // Config of the transform, without the transforms.<name>. prefix.
+    // +optional
+    Config map[string]string `json:"config,omitempty"`
+    
+    // Predicate is the name of a predicate the transform is conditionally
+    // applied with. The transform is only applied to records matching it.
+    // +optional
+    // +kubebuilder:validation:MaxLength=64
+    Predicate string `json:"predicate,omitempty"`
+    
+    // Negate the predicate, i.e. apply the transform only to records not
+    // matching it.
+    // +optional
+    Negate bool `json:"negate,omitempty"`
+}
+
+// A Predicate decides which records a transform is applied to.
+type Predicate struct {
+    // Name of the predicate, unique within the connector.
+    // +kubebuilder:validation:Pattern=`^[A-Za-z0-9_-]+$`
+    // +kubebuilder:validation:MaxLength=64
+    Name string `json:"name"`
+    
+    // Type is the Java class of the predicate.
+    // +kubebuilder:validation:MinLength=1
+    Type string `json:"type"`
+    
+    // Config of the predicate, without the predicates.<name>. prefix.
+    // +optional
+    Config map[string]string `json:"config,omitempty"`
+}
+
+// ErrorHandling configures how a connector handles records it fails to
+// process.
+// +kubebuilder:validation:XValidation:rule="!has(self.deadLetterQueue) || self.tolerance == 'all'",message="a dead letter queue requires tolerance all"
+type ErrorHandling struct {
+    // Tolerance for errors. With none the task fails on the first error, with
+    // all records that cannot be processed are skipped.
+    // +kubebuilder:validation:Enum=none;all
+    // +kubebuilder:default=none
+    // +optional
+    Tolerance string `json:"tolerance,omitempty"`
+    
+    // RetryTimeout is how long a failed operation is retried for. Operations
+    // are not retried if it is zero, and retried forever if it is negative.
+    // +optional
+    RetryTimeout *metav1.Duration `json:"retryTimeout,omitempty"`
+    
+    // RetryDelayMax is the maximum delay between retries of a failed
+    // operation.
+    // +optional
+    RetryDelayMax *metav1.Duration `json:"retryDelayMax,omitempty"`
+    
+    // Log configures logging of errors.
+    // +optional
+    Log *ErrorLog `json:"log,omitempty"`
+    
+    // DeadLetterQueue configures the topic records a sink connector fails to
+    // process are written to. It requires tolerance all.
+    // +optional
+    DeadLetterQueue *DeadLetterQueue `json:"deadLetterQueue,omitempty"`
+}
+
+// ErrorLog configures logging of the errors of a connector.
+type ErrorLog struct {
+    // Enable logging of errors, and of failed operations that are retried.
+    Enable bool `json:"enable"`
+    
+    // IncludeMessages includes the records that failed to be processed in
+    // the log.
+    // +optional
+    IncludeMessages bool `json:"includeMessages,omitempty"`
+}
+
+// A DeadLetterQueue is a topic records a sink connector fails to process are
+// written to.
+type DeadLetterQueue struct {
+    // TopicName of the dead letter queue.
+    // +kubebuilder:validation:Pattern=`^[a-zA-Z0-9._-]+$`
+    // +kubebuilder:validation:MaxLength=249
+    TopicName string `json:"topicName"`
+    
+    // ReplicationFactor of the topic, if it is created by the connector.
+    // +kubebuilder:validation:Minimum=1
+    // +optional
+    ReplicationFactor *int `json:"replicationFactor,omitempty"`
+    
+    // ContextHeaders adds headers describing the error to the records
+    // written to the dead letter queue.
+    // +optional
+    ContextHeaders bool `json:"contextHeaders,omitempty"`
+}
+
+// Converters convert between the records of a connector and the bytes
+// stored in Kafka. The converters of the worker are used if none are
+// configured.
+type Converters struct {
+    // Key converter.
+    // +optional
+    Key *Converter `json:"key,omitempty"`
+    
+    // Value converter.
+    // +optional
+    Value *Converter `json:"value,omitempty"`
+    
+    // Header converter.
+    // +optional
+    Header *Converter `json:"header,omitempty"`
+}
+
+// A Converter of the keys, values or headers of records.
+type Converter struct {
+    // Class of the converter, e.g.
+    // org.apache.kafka.connect.json.JsonConverter.
+    // +kubebuilder:validation:MinLength=1
+    Class string `json:"class"`
+    
+    // Config of the converter, without the <key|value|header>.converter.
+    // prefix, e.g. schemas.enable or schema.registry.url.
+    // +optional
+    Config map[string]string `json:"config,omitempty"`
+    
+    // SchemaRegistryCredentialsSecretRef references a Secret key holding the
+    // credentials of the schema registry as username:password. They are
+    // passed to the converter as basic auth user info. Note that Kafka
+    // Connect returns them in plain text as part of the connector config.
+    // +optional
+    SchemaRegistryCredentialsSecretRef *xpv1.SecretKeySelector `json:"schemaRegistryCredentialsSecretRef,omitempty"`
+}
+
+// TopicCreation configures the topics a source connector creates.
+type TopicCreation struct {
+    // Default settings of created topics, used for topics no group matches.
+    Default TopicCreationDefault `json:"default"`
+    
+    // Groups of topics created with other settings than the default ones.
+    // A topic is created with the settings of the first group matching it.
+    // +optional
+    // +listType=map
+    // +listMapKey=name
+    // +kubebuilder:validation:MaxItems=32
+    Groups []TopicCreationGroup `json:"groups,omitempty"`
+}
+
+// TopicCreationDefault are the default settings of the topics a source
+// connector creates.
+type TopicCreationDefault struct {
+    // ReplicationFactor of created topics, or -1 for the broker's default.
+    // +kubebuilder:validation:Minimum=-1
+    // +kubebuilder:validation:XValidation:rule="self != 0",message="replicationFactor must be positive or -1"
+    ReplicationFactor int `json:"replicationFactor"`
+    
+    // Partitions of created topics, or -1 for the broker's default.
+    // +kubebuilder:validation:Minimum=-1
+    // +kubebuilder:validation:XValidation:rule="self != 0",message="partitions must be positive or -1"
+    Partitions int `json:"partitions"`
+    
+    // Config of created topics, e.g. cleanup.policy.
+    // +optional
+    Config map[string]string `json:"config,omitempty"`
+}
+
+// A TopicCreationGroup are the settings of the topics matching it. Settings
+// that are not set are inherited from the default settings.
+type TopicCreationGroup struct {
+    // Name of the group.
+    // +kubebuilder:validation:Pattern=`^[A-Za-z0-9_-]+$`
+    // +kubebuilder:validation:MaxLength=64
+    // +kubebuilder:validation:XValidation:rule="self != 'default'",message="the default group is configured by default"
+    Name string `json:"name"`
+    
+    // Include are regular expressions matching the names of the topics in
+    // the group.
+    // +kubebuilder:validation:MinItems=1
+    // +kubebuilder:validation:items:Pattern=`^[^,]+$`
+    Include []string `json:"include"`
+    
+    // Exclude are regular expressions matching the names of topics excluded
+    // from the group, even if they are included.
+    // +optional
+    // +kubebuilder:validation:items:Pattern=`^[^,]+$`
+    Exclude []string `json:"exclude,omitempty"`
+    
+    // ReplicationFactor of the topics in the group, or -1 for the broker's
+    // default.
+    // +optional
+    // +kubebuilder:validation:Minimum=-1
+    // +kubebuilder:validation:XValidation:rule="self != 0",message="replicationFactor must be positive or -1"
+    ReplicationFactor *int `json:"replicationFactor,omitempty"`
+    
+    // Partitions of the topics in the group, or -1 for the broker's default.
+    // +optional
+    // +kubebuilder:validation:Minimum=-1
+    // +kubebuilder:validation:XValidation:rule="self != 0",message="partitions must be positive or -1"
+    Partitions *int `json:"partitions,omitempty"`
+    
+    // Config of the topics in the group, e.g. cleanup.policy.
+    // +optional
+    Config map[string]string `json:"config,omitempty"`
+}
+
 // ConnectorObservation are the observable fields of a Connector.
 type ConnectorObservation struct {
     // State of the connector
@@ -41,6 +324,14 @@ type ConnectorObservation struct {
     
     // Tasks information
     Tasks []TaskStatus `json:"tasks,omitempty"`
+    
+    // KafkaConnectURL is the URL of the Kafka Connect worker the connector
+    // was last observed through.
+    KafkaConnectURL string `json:"kafkaConnectUrl,omitempty"`
+    
+    // LastRestartTime is when the provider last restarted the connector or
+    // its tasks because they failed.
+    LastRestartTime *metav1.Time `json:"lastRestartTime,omitempty"`
 }
 
 // TaskStatus represents the status of a connector task
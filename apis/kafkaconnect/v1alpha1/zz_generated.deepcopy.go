@@ -9,9 +9,32 @@
 package v1alpha1
 
 import (
+	commonv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	runtime "k8s.io/apimachinery/pkg/runtime"
 )
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AutoRestart) DeepCopyInto(out *AutoRestart) {
+	*out = *in
+	if in.MinInterval != nil {
+		in, out := &in.MinInterval, &out.MinInterval
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AutoRestart.
+func (in *AutoRestart) DeepCopy() *AutoRestart {
+	if in == nil {
+		return nil
+	}
+	out := new(AutoRestart)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Connector) DeepCopyInto(out *Connector) {
 	*out = *in
@@ -79,6 +102,10 @@ func (in *ConnectorObservation) DeepCopyInto(out *ConnectorObservation) {
 		*out = make([]TaskStatus, len(*in))
 		copy(*out, *in)
 	}
+	if in.LastRestartTime != nil {
+		in, out := &in.LastRestartTime, &out.LastRestartTime
+		*out = (*in).DeepCopy()
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ConnectorObservation.
@@ -96,11 +123,45 @@ func (in *ConnectorParameters) DeepCopyInto(out *ConnectorParameters) {
 	*out = *in
 	if in.Config != nil {
 		in, out := &in.Config, &out.Config
-		*out = make(map[string]string, len(*in))
+		*out = make(map[string]v1.JSON, len(*in))
 		for key, val := range *in {
-			(*out)[key] = val
+			(*out)[key] = *val.DeepCopy()
+		}
+	}
+	if in.Transforms != nil {
+		in, out := &in.Transforms, &out.Transforms
+		*out = make([]Transform, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Predicates != nil {
+		in, out := &in.Predicates, &out.Predicates
+		*out = make([]Predicate, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.ErrorHandling != nil {
+		in, out := &in.ErrorHandling, &out.ErrorHandling
+		*out = new(ErrorHandling)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Converters != nil {
+		in, out := &in.Converters, &out.Converters
+		*out = new(Converters)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.TopicCreation != nil {
+		in, out := &in.TopicCreation, &out.TopicCreation
+		*out = new(TopicCreation)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.AutoRestart != nil {
+		in, out := &in.AutoRestart, &out.AutoRestart
+		*out = new(AutoRestart)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ConnectorParameters.
@@ -270,6 +331,155 @@ func (in *ConnectorStatus) DeepCopy() *ConnectorStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Converter) DeepCopyInto(out *Converter) {
+	*out = *in
+	if in.Config != nil {
+		in, out := &in.Config, &out.Config
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.SchemaRegistryCredentialsSecretRef != nil {
+		in, out := &in.SchemaRegistryCredentialsSecretRef, &out.SchemaRegistryCredentialsSecretRef
+		*out = new(commonv1.SecretKeySelector)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Converter.
+func (in *Converter) DeepCopy() *Converter {
+	if in == nil {
+		return nil
+	}
+	out := new(Converter)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Converters) DeepCopyInto(out *Converters) {
+	*out = *in
+	if in.Key != nil {
+		in, out := &in.Key, &out.Key
+		*out = new(Converter)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Value != nil {
+		in, out := &in.Value, &out.Value
+		*out = new(Converter)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Header != nil {
+		in, out := &in.Header, &out.Header
+		*out = new(Converter)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Converters.
+func (in *Converters) DeepCopy() *Converters {
+	if in == nil {
+		return nil
+	}
+	out := new(Converters)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DeadLetterQueue) DeepCopyInto(out *DeadLetterQueue) {
+	*out = *in
+	if in.ReplicationFactor != nil {
+		in, out := &in.ReplicationFactor, &out.ReplicationFactor
+		*out = new(int)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DeadLetterQueue.
+func (in *DeadLetterQueue) DeepCopy() *DeadLetterQueue {
+	if in == nil {
+		return nil
+	}
+	out := new(DeadLetterQueue)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ErrorHandling) DeepCopyInto(out *ErrorHandling) {
+	*out = *in
+	if in.RetryTimeout != nil {
+		in, out := &in.RetryTimeout, &out.RetryTimeout
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+	if in.RetryDelayMax != nil {
+		in, out := &in.RetryDelayMax, &out.RetryDelayMax
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+	if in.Log != nil {
+		in, out := &in.Log, &out.Log
+		*out = new(ErrorLog)
+		**out = **in
+	}
+	if in.DeadLetterQueue != nil {
+		in, out := &in.DeadLetterQueue, &out.DeadLetterQueue
+		*out = new(DeadLetterQueue)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ErrorHandling.
+func (in *ErrorHandling) DeepCopy() *ErrorHandling {
+	if in == nil {
+		return nil
+	}
+	out := new(ErrorHandling)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ErrorLog) DeepCopyInto(out *ErrorLog) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ErrorLog.
+func (in *ErrorLog) DeepCopy() *ErrorLog {
+	if in == nil {
+		return nil
+	}
+	out := new(ErrorLog)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Predicate) DeepCopyInto(out *Predicate) {
+	*out = *in
+	if in.Config != nil {
+		in, out := &in.Config, &out.Config
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Predicate.
+func (in *Predicate) DeepCopy() *Predicate {
+	if in == nil {
+		return nil
+	}
+	out := new(Predicate)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *TaskStatus) DeepCopyInto(out *TaskStatus) {
 	*out = *in
@@ -284,3 +494,112 @@ func (in *TaskStatus) DeepCopy() *TaskStatus {
 	in.DeepCopyInto(out)
 	return out
 }
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TopicCreation) DeepCopyInto(out *TopicCreation) {
+	*out = *in
+	in.Default.DeepCopyInto(&out.Default)
+	if in.Groups != nil {
+		in, out := &in.Groups, &out.Groups
+		*out = make([]TopicCreationGroup, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TopicCreation.
+func (in *TopicCreation) DeepCopy() *TopicCreation {
+	if in == nil {
+		return nil
+	}
+	out := new(TopicCreation)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TopicCreationDefault) DeepCopyInto(out *TopicCreationDefault) {
+	*out = *in
+	if in.Config != nil {
+		in, out := &in.Config, &out.Config
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TopicCreationDefault.
+func (in *TopicCreationDefault) DeepCopy() *TopicCreationDefault {
+	if in == nil {
+		return nil
+	}
+	out := new(TopicCreationDefault)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TopicCreationGroup) DeepCopyInto(out *TopicCreationGroup) {
+	*out = *in
+	if in.Include != nil {
+		in, out := &in.Include, &out.Include
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Exclude != nil {
+		in, out := &in.Exclude, &out.Exclude
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.ReplicationFactor != nil {
+		in, out := &in.ReplicationFactor, &out.ReplicationFactor
+		*out = new(int)
+		**out = **in
+	}
+	if in.Partitions != nil {
+		in, out := &in.Partitions, &out.Partitions
+		*out = new(int)
+		**out = **in
+	}
+	if in.Config != nil {
+		in, out := &in.Config, &out.Config
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TopicCreationGroup.
+func (in *TopicCreationGroup) DeepCopy() *TopicCreationGroup {
+	if in == nil {
+		return nil
+	}
+	out := new(TopicCreationGroup)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Transform) DeepCopyInto(out *Transform) {
+	*out = *in
+	if in.Config != nil {
+		in, out := &in.Config, &out.Config
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Transform.
+func (in *Transform) DeepCopy() *Transform {
+	if in == nil {
+		return nil
+	}
+	out := new(Transform)
+	in.DeepCopyInto(out)
+	return out
+}
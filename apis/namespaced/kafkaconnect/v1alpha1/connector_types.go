@@ -0,0 +1,180 @@
+/*
+Copyright 2025 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"reflect"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
+
+	kcv1alpha1 "github.com/crossplane/provider-kafkaconnect/apis/kafkaconnect/v1alpha1"
+	nsv1alpha1 "github.com/crossplane/provider-kafkaconnect/apis/namespaced/v1alpha1"
+)
+
+// A ConnectorSpec defines the desired state of a namespaced Connector.
+type ConnectorSpec struct {
+	// ProviderConfigReference specifies the ProviderConfig in the namespace
+	// of the Connector, or the ClusterProviderConfig, used to connect to
+	// Kafka Connect.
+	// +kubebuilder:default={"kind": "ClusterProviderConfig", "name": "default"}
+	// +optional
+	ProviderConfigReference *nsv1alpha1.ProviderConfigReference `json:"providerConfigRef,omitempty"`
+
+	// ManagementPolicies specify the array of actions Crossplane is allowed to
+	// take on the managed and external resources.
+	// +kubebuilder:default={"*"}
+	// +optional
+	ManagementPolicies xpv1.ManagementPolicies `json:"managementPolicies,omitempty"`
+
+	// DeletionPolicy specifies what will happen to the connector when this
+	// managed resource is deleted.
+	// +kubebuilder:validation:Enum=Orphan;Delete
+	// +kubebuilder:default=Delete
+	// +optional
+	DeletionPolicy xpv1.DeletionPolicy `json:"deletionPolicy,omitempty"`
+
+	// ForProvider are the parameters of the connector. Secrets referenced by
+	// them must be in the namespace of the Connector.
+	ForProvider kcv1alpha1.ConnectorParameters `json:"forProvider"`
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:printcolumn:name="STATE",type="string",JSONPath=".status.atProvider.state"
+// +kubebuilder:printcolumn:name="READY",type="string",JSONPath=".status.conditions[?(@.type=='Ready')].status"
+// +kubebuilder:printcolumn:name="SYNCED",type="string",JSONPath=".status.conditions[?(@.type=='Synced')].status"
+// +kubebuilder:printcolumn:name="AGE",type="date",JSONPath=".metadata.creationTimestamp"
+// +kubebuilder:subresource:status
+// +kubebuilder:resource:scope=Namespaced,categories={crossplane,managed,kafkaconnect}
+
+// A Connector is a namespaced managed resource representing a Kafka Connect
+// connector. Unlike its cluster scoped counterpart it does not write
+// connection details.
+type Connector struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   ConnectorSpec              `json:"spec"`
+	Status kcv1alpha1.ConnectorStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// ConnectorList contains a list of Connector
+type ConnectorList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []Connector `json:"items"`
+}
+
+// Connector type metadata.
+var (
+	ConnectorKind             = reflect.TypeOf(Connector{}).Name()
+	ConnectorGroupKind        = schema.GroupKind{Group: Group, Kind: ConnectorKind}.String()
+	ConnectorKindAPIVersion   = ConnectorKind + "." + SchemeGroupVersion.String()
+	ConnectorGroupVersionKind = SchemeGroupVersion.WithKind(ConnectorKind)
+)
+
+func init() {
+	SchemeBuilder.Register(&Connector{}, &ConnectorList{})
+}
+
+// GetCondition of this Connector.
+func (mg *Connector) GetCondition(ct xpv1.ConditionType) xpv1.Condition {
+	return mg.Status.GetCondition(ct)
+}
+
+// GetDeletionPolicy of this Connector.
+func (mg *Connector) GetDeletionPolicy() xpv1.DeletionPolicy {
+	return mg.Spec.DeletionPolicy
+}
+
+// GetManagementPolicies of this Connector.
+func (mg *Connector) GetManagementPolicies() xpv1.ManagementPolicies {
+	return mg.Spec.ManagementPolicies
+}
+
+// GetProviderConfigReference of this Connector. The kind of the provider
+// config is dropped; use Spec.ProviderConfigReference to get it.
+func (mg *Connector) GetProviderConfigReference() *xpv1.Reference {
+	if mg.Spec.ProviderConfigReference == nil {
+		return nil
+	}
+	return &xpv1.Reference{Name: mg.Spec.ProviderConfigReference.Name}
+}
+
+// GetPublishConnectionDetailsTo of this Connector. Namespaced Connectors do
+// not publish connection details.
+func (mg *Connector) GetPublishConnectionDetailsTo() *xpv1.PublishConnectionDetailsTo {
+	return nil
+}
+
+// GetWriteConnectionSecretToReference of this Connector. Namespaced
+// Connectors do not write connection details.
+func (mg *Connector) GetWriteConnectionSecretToReference() *xpv1.SecretReference {
+	return nil
+}
+
+// SetConditions of this Connector.
+func (mg *Connector) SetConditions(c ...xpv1.Condition) {
+	mg.Status.SetConditions(c...)
+}
+
+// SetDeletionPolicy of this Connector.
+func (mg *Connector) SetDeletionPolicy(r xpv1.DeletionPolicy) {
+	mg.Spec.DeletionPolicy = r
+}
+
+// SetManagementPolicies of this Connector.
+func (mg *Connector) SetManagementPolicies(r xpv1.ManagementPolicies) {
+	mg.Spec.ManagementPolicies = r
+}
+
+// SetProviderConfigReference of this Connector. It references a
+// ClusterProviderConfig unless the Connector already references a
+// ProviderConfig.
+func (mg *Connector) SetProviderConfigReference(r *xpv1.Reference) {
+	if r == nil {
+		mg.Spec.ProviderConfigReference = nil
+		return
+	}
+	kind := nsv1alpha1.ClusterProviderConfigKind
+	if mg.Spec.ProviderConfigReference != nil {
+		kind = mg.Spec.ProviderConfigReference.Kind
+	}
+	mg.Spec.ProviderConfigReference = &nsv1alpha1.ProviderConfigReference{Kind: kind, Name: r.Name}
+}
+
+// SetPublishConnectionDetailsTo of this Connector. It is a no-op, since
+// namespaced Connectors do not publish connection details.
+func (mg *Connector) SetPublishConnectionDetailsTo(_ *xpv1.PublishConnectionDetailsTo) {}
+
+// SetWriteConnectionSecretToReference of this Connector. It is a no-op, since
+// namespaced Connectors do not write connection details.
+func (mg *Connector) SetWriteConnectionSecretToReference(_ *xpv1.SecretReference) {}
+
+// GetItems of this ConnectorList.
+func (l *ConnectorList) GetItems() []resource.Managed {
+	items := make([]resource.Managed, len(l.Items))
+	for i := range l.Items {
+		items[i] = &l.Items[i]
+	}
+	return items
+}
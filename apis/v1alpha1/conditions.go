@@ -0,0 +1,66 @@
+/*
+Copyright 2025 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+)
+
+// Reasons a ProviderConfig is or is not ready.
+const (
+	ReasonHealthy         xpv1.ConditionReason = "Healthy"
+	ReasonUnhealthy       xpv1.ConditionReason = "Unhealthy"
+	ReasonClusterMismatch xpv1.ConditionReason = "ClusterMismatch"
+)
+
+// Healthy returns a condition that indicates the Kafka Connect cluster of a
+// ProviderConfig passed its last health check.
+func Healthy() xpv1.Condition {
+	return xpv1.Condition{
+		Type:               xpv1.TypeReady,
+		Status:             corev1.ConditionTrue,
+		LastTransitionTime: metav1.Now(),
+		Reason:             ReasonHealthy,
+	}
+}
+
+// Unhealthy returns a condition that indicates the Kafka Connect cluster of a
+// ProviderConfig failed its last health check with the supplied error.
+func Unhealthy(err error) xpv1.Condition {
+	return xpv1.Condition{
+		Type:               xpv1.TypeReady,
+		Status:             corev1.ConditionFalse,
+		LastTransitionTime: metav1.Now(),
+		Reason:             ReasonUnhealthy,
+		Message:            err.Error(),
+	}
+}
+
+// ClusterMismatch returns a condition that indicates the Kafka Connect
+// cluster of a ProviderConfig is not the expected one.
+func ClusterMismatch(err error) xpv1.Condition {
+	return xpv1.Condition{
+		Type:               xpv1.TypeReady,
+		Status:             corev1.ConditionFalse,
+		LastTransitionTime: metav1.Now(),
+		Reason:             ReasonClusterMismatch,
+		Message:            err.Error(),
+	}
+}
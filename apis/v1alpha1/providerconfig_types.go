@@ -10,18 +10,158 @@ import (
 )
 
 // ProviderConfigSpec defines the desired state of ProviderConfig.
+// +kubebuilder:validation:XValidation:rule="(has(self.kafkaConnectUrl) || has(self.kafkaConnectUrls)) != has(self.serviceRef)",message="exactly one of kafkaConnectUrl, kafkaConnectUrls or serviceRef must be set"
 type ProviderConfigSpec struct {
     // Credentials required to authenticate to Kafka Connect.
     // +optional
     Credentials ProviderCredentials `json:"credentials"`
     
     // KafkaConnectURL is the base URL of the Kafka Connect instance
-    // +kubebuilder:validation:Required
-    KafkaConnectURL string `json:"kafkaConnectUrl"`
+    // +optional
+    KafkaConnectURL string `json:"kafkaConnectUrl,omitempty"`
+    
+    // KafkaConnectURLs are the base URLs of the workers of the Kafka Connect
+    // cluster. Requests are sent to one worker at a time, and fail over to the
+    // next one if it is unreachable or cannot serve requests. If
+    // KafkaConnectURL is set too, it is tried first.
+    // +optional
+    // +kubebuilder:validation:MinItems=1
+    KafkaConnectURLs []string `json:"kafkaConnectUrls,omitempty"`
+    
+    // ServiceRef references a Kubernetes Service in front of the workers of
+    // the Kafka Connect cluster. It is resolved whenever a client is created,
+    // so changes to the Service are followed.
+    // +optional
+    ServiceRef *ServiceReference `json:"serviceRef,omitempty"`
+    
+    // AllowedConnectorURLHosts are the hosts Connectors using this
+    // ProviderConfig may point at with their own KafkaConnectURL. Entries are
+    // host names, optionally with a port, or wildcards like *.example.com that
+    // match any subdomain. Connectors may not override the URL if it is empty.
+    // The credentials and TLS configuration of this ProviderConfig are used
+    // for overridden URLs too.
+    // +optional
+    AllowedConnectorURLHosts []string `json:"allowedConnectorUrlHosts,omitempty"`
     
     // TLS configuration for connecting to Kafka Connect
     // +optional
     TLS *TLSConfig `json:"tls,omitempty"`
+    
+    // MaxConcurrentRequests is the maximum number of requests in flight to
+    // the Kafka Connect cluster. Further requests wait. Unlimited if unset.
+    // +optional
+    // +kubebuilder:validation:Minimum=1
+    MaxConcurrentRequests *int `json:"maxConcurrentRequests,omitempty"`
+    
+    // RequestsPerSecond is the maximum rate of requests to the Kafka Connect
+    // cluster, with bursts of up to the same number of requests. Further
+    // requests wait. Unlimited if unset.
+    // +optional
+    // +kubebuilder:validation:Minimum=1
+    RequestsPerSecond *int `json:"requestsPerSecond,omitempty"`
+    
+    // Transport configures the HTTP connections to Kafka Connect.
+    // +optional
+    Transport *TransportConfig `json:"transport,omitempty"`
+    
+    // ExpectedKafkaClusterID is the ID of the Kafka cluster the Kafka Connect
+    // cluster must be connected to. If set, it is verified against the
+    // kafka_cluster_id reported by the worker before any mutating call, and
+    // Connectors using this ProviderConfig stop reconciling on a mismatch.
+    // +optional
+    ExpectedKafkaClusterID string `json:"expectedKafkaClusterID,omitempty"`
+    
+    // ExpectedConnectGroup is the group ID of the Kafka Connect cluster. If
+    // set, it is verified against the group_id reported by the worker's root
+    // endpoint before any mutating call. Workers that do not report their
+    // group ID, which includes Apache Kafka workers, fail the verification.
+    // +optional
+    ExpectedConnectGroup string `json:"expectedConnectGroup,omitempty"`
+}
+
+// A ServiceReference references a Kubernetes Service in front of the workers
+// of a Kafka Connect cluster.
+type ServiceReference struct {
+    // Namespace of the Service.
+    Namespace string `json:"namespace"`
+    
+    // Name of the Service.
+    Name string `json:"name"`
+    
+    // Port is the name of the Service port the REST API is served on. It may
+    // be omitted if the Service has a single port.
+    // +optional
+    Port string `json:"port,omitempty"`
+    
+    // Scheme of the REST API.
+    // +optional
+    // +kubebuilder:validation:Enum=http;https
+    // +kubebuilder:default=http
+    Scheme string `json:"scheme,omitempty"`
+    
+    // ResolveEndpoints resolves the Service to the addresses of its ready
+    // endpoints rather than to its DNS name, so that requests fail over
+    // between workers like they do for kafkaConnectUrls. With https, worker
+    // certificates must be valid for their pod IPs.
+    // +optional
+    ResolveEndpoints bool `json:"resolveEndpoints,omitempty"`
+}
+
+// TransportConfig configures the HTTP connections to Kafka Connect.
+// +kubebuilder:validation:XValidation:rule="!has(self.noProxy) || has(self.proxyUrl)",message="noProxy requires a proxyUrl"
+type TransportConfig struct {
+    // Timeout of a request, including reading the response. Some validation
+    // calls take a while on large connectors.
+    // +optional
+    // +kubebuilder:default="30s"
+    Timeout *metav1.Duration `json:"timeout,omitempty"`
+    
+    // DialTimeout is how long to wait for a connection to be established.
+    // +optional
+    DialTimeout *metav1.Duration `json:"dialTimeout,omitempty"`
+    
+    // TLSHandshakeTimeout is how long to wait for a TLS handshake.
+    // +optional
+    TLSHandshakeTimeout *metav1.Duration `json:"tlsHandshakeTimeout,omitempty"`
+    
+    // ResponseHeaderTimeout is how long to wait for the response headers
+    // once a request is sent.
+    // +optional
+    ResponseHeaderTimeout *metav1.Duration `json:"responseHeaderTimeout,omitempty"`
+    
+    // IdleConnTimeout is how long idle connections are kept alive.
+    // +optional
+    IdleConnTimeout *metav1.Duration `json:"idleConnTimeout,omitempty"`
+    
+    // ProxyURL is the URL of the HTTP proxy Kafka Connect is reached through.
+    // The proxy is taken from the HTTP_PROXY, HTTPS_PROXY and NO_PROXY
+    // environment variables of the provider if it is not set.
+    // +optional
+    ProxyURL string `json:"proxyUrl,omitempty"`
+    
+    // NoProxy are the hosts that are reached without the proxy, in the
+    // format of the NO_PROXY environment variable, e.g. example.com,
+    // .example.com or 10.0.0.0/8. It may only be set together with ProxyURL.
+    // The NO_PROXY environment variable of the provider applies otherwise.
+    // +optional
+    NoProxy []string `json:"noProxy,omitempty"`
+    
+    // MaxIdleConns is the maximum number of idle connections to all workers.
+    // +optional
+    // +kubebuilder:validation:Minimum=0
+    MaxIdleConns *int `json:"maxIdleConns,omitempty"`
+    
+    // MaxIdleConnsPerHost is the maximum number of idle connections to each
+    // worker.
+    // +optional
+    // +kubebuilder:validation:Minimum=0
+    MaxIdleConnsPerHost *int `json:"maxIdleConnsPerHost,omitempty"`
+    
+    // MaxConnsPerHost is the maximum number of connections to each worker,
+    // including those in use. Zero means no limit.
+    // +optional
+    // +kubebuilder:validation:Minimum=0
+    MaxConnsPerHost *int `json:"maxConnsPerHost,omitempty"`
 }
 
 // TLSConfig contains TLS configuration
@@ -47,6 +187,32 @@ type ProviderCredentials struct {
 // ProviderConfigStatus defines the observed state of ProviderConfig.
 type ProviderConfigStatus struct {
     xpv1.ProviderConfigStatus `json:",inline"`
+    
+    // ActiveEndpoint is the Kafka Connect worker URL requests are currently
+    // sent to.
+    // +optional
+    ActiveEndpoint string `json:"activeEndpoint,omitempty"`
+    
+    // Version of the Kafka Connect worker as of the last successful health
+    // check.
+    // +optional
+    Version string `json:"version,omitempty"`
+    
+    // Commit of the Kafka Connect worker as of the last successful health
+    // check.
+    // +optional
+    Commit string `json:"commit,omitempty"`
+    
+    // KafkaClusterID is the ID of the Kafka cluster the Kafka Connect cluster
+    // is connected to, as of the last successful health check.
+    // +optional
+    KafkaClusterID string `json:"kafkaClusterId,omitempty"`
+    
+    // CircuitBreaker is the state of the circuit breaker of the Kafka Connect
+    // cluster. Requests fail fast while it is Open, and a single probe request
+    // is let through while it is HalfOpen.
+    // +optional
+    CircuitBreaker string `json:"circuitBreaker,omitempty"`
 }
 
 // +kubebuilder:object:root=true
@@ -58,6 +224,9 @@ type ProviderConfigStatus struct {
 
 // ProviderConfig is the Schema for the ProviderConfigs API.
 // +kubebuilder:printcolumn:name="AGE",type="date",JSONPath=".metadata.creationTimestamp"
+// +kubebuilder:printcolumn:name="READY",type="string",JSONPath=".status.conditions[?(@.type=='Ready')].status"
+// +kubebuilder:printcolumn:name="VERSION",type="string",JSONPath=".status.version"
+// +kubebuilder:printcolumn:name="ENDPOINT",type="string",JSONPath=".status.activeEndpoint"
 // +kubebuilder:printcolumn:name="SECRET-NAME",type="string",JSONPath=".spec.credentials.secretRef.name",priority=1
 // +kubebuilder:resource:scope=Cluster,categories={crossplane,provider,kafkaconnect}
 // +kubebuilder:subresource:status
@@ -86,6 +255,21 @@ var (
     ProviderConfigGroupVersionKind = SchemeGroupVersion.WithKind(ProviderConfigKind)
 )
 
+// Endpoints returns the Kafka Connect worker URLs of this ProviderConfig, in
+// order of preference.
+func (pc *ProviderConfig) Endpoints() []string {
+    urls := make([]string, 0, len(pc.Spec.KafkaConnectURLs)+1)
+    if pc.Spec.KafkaConnectURL != "" {
+        urls = append(urls, pc.Spec.KafkaConnectURL)
+    }
+    for _, u := range pc.Spec.KafkaConnectURLs {
+        if u != pc.Spec.KafkaConnectURL {
+            urls = append(urls, u)
+        }
+    }
+    return urls
+}
+
 func init() {
     SchemeBuilder.Register(&ProviderConfig{}, &ProviderConfigList{})
 }
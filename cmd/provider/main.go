@@ -49,6 +49,9 @@ import (
 	"github.com/crossplane/provider-kafkaconnect/apis/v1alpha1"
 	kafkaconnect "github.com/crossplane/provider-kafkaconnect/internal/controller"
 	"github.com/crossplane/provider-kafkaconnect/internal/features"
+	kcmetrics "github.com/crossplane/provider-kafkaconnect/internal/metrics"
+	"github.com/crossplane/provider-kafkaconnect/internal/options"
+	"github.com/crossplane/provider-kafkaconnect/internal/tracing"
 	"github.com/crossplane/provider-kafkaconnect/internal/version"
 )
 
@@ -61,6 +64,8 @@ func main() {
 		syncInterval            = app.Flag("sync", "How often all resources will be double-checked for drift from the desired state.").Short('s').Default("1h").Duration()
 		pollInterval            = app.Flag("poll", "How often individual resources will be checked for drift from the desired state").Default("1m").Duration()
 		pollStateMetricInterval = app.Flag("poll-state-metric", "State metric recording interval").Default("5s").Duration()
+		healthCheckInterval     = app.Flag("health-check", "How often the Kafka Connect cluster of each ProviderConfig is checked for health.").Default("1m").Duration()
+		statusPollInterval      = app.Flag("status-poll", "How often the status of all connectors of each Kafka Connect cluster is polled at once. Set to 0 to observe each connector individually.").Default("30s").Duration()
 
 		maxReconcileRate = app.Flag("max-reconcile-rate", "The global maximum rate per second at which resources may checked for drift from the desired state.").Default("10").Int()
 
@@ -69,6 +74,11 @@ func main() {
 		enableManagementPolicies   = app.Flag("enable-management-policies", "Enable support for Management Policies.").Default("false").Envar("ENABLE_MANAGEMENT_POLICIES").Bool()
 		enableChangeLogs           = app.Flag("enable-changelogs", "Enable support for capturing change logs during reconciliation.").Default("false").Envar("ENABLE_CHANGE_LOGS").Bool()
 		changelogsSocketPath       = app.Flag("changelogs-socket-path", "Path for changelogs socket (if enabled)").Default("/var/run/changelogs/changelogs.sock").Envar("CHANGELOGS_SOCKET_PATH").String()
+
+		enableTracing      = app.Flag("enable-tracing", "Enable OpenTelemetry tracing of reconciles and Kafka Connect calls.").Default("false").Envar("ENABLE_TRACING").Bool()
+		tracingEndpoint    = app.Flag("tracing-endpoint", "OTLP gRPC endpoint spans are exported to (if enabled).").Default("localhost:4317").Envar("TRACING_ENDPOINT").String()
+		tracingInsecure    = app.Flag("tracing-insecure", "Disable TLS when exporting spans (if enabled).").Default("false").Envar("TRACING_INSECURE").Bool()
+		tracingSampleRatio = app.Flag("tracing-sample-ratio", "Fraction of reconciles that are traced (if enabled).").Default("1").Envar("TRACING_SAMPLE_RATIO").Float64()
 	)
 	kingpin.MustParse(app.Parse(os.Args[1:]))
 
@@ -85,6 +95,18 @@ func main() {
 		ctrl.SetLogger(zap.New(zap.WriteTo(io.Discard)))
 	}
 
+	if *enableTracing {
+		shutdown, err := tracing.Setup(context.Background(), tracing.Options{
+			Endpoint:       *tracingEndpoint,
+			Insecure:       *tracingInsecure,
+			SampleRatio:    *tracingSampleRatio,
+			ServiceVersion: version.Version,
+		})
+		kingpin.FatalIfError(err, "Cannot setup tracing")
+		defer shutdown(context.Background()) //nolint:errcheck // Nothing to do if flushing spans fails on exit.
+		log.Info("Tracing enabled", "endpoint", *tracingEndpoint)
+	}
+
 	cfg, err := ctrl.GetConfig()
 	kingpin.FatalIfError(err, "Cannot get API server rest config")
 
@@ -116,6 +138,7 @@ func main() {
 
 	metrics.Registry.MustRegister(metricRecorder)
 	metrics.Registry.MustRegister(stateMetrics)
+	metrics.Registry.MustRegister(kcmetrics.Collectors()...)
 
 	o := controller.Options{
 		Logger:                  log,
@@ -169,6 +192,10 @@ func main() {
 		o.ChangeLogOptions = &clo
 	}
 
-	kingpin.FatalIfError(kafkaconnect.Setup(mgr, o), "Cannot setup KafkaConnect controllers")
+	kingpin.FatalIfError(kafkaconnect.Setup(mgr, options.Options{
+		Options:             o,
+		StatusPollInterval:  *statusPollInterval,
+		HealthCheckInterval: *healthCheckInterval,
+	}), "Cannot setup KafkaConnect controllers")
 	kingpin.FatalIfError(mgr.Start(ctrl.SetupSignalHandler()), "Cannot start controller manager")
 }
@@ -0,0 +1,210 @@
+package kafkaconnect
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// A BreakerState is the state of a circuit breaker.
+type BreakerState string
+
+// Circuit breaker states.
+const (
+	// BreakerClosed lets all requests through.
+	BreakerClosed BreakerState = "Closed"
+
+	// BreakerOpen fails all requests without sending them.
+	BreakerOpen BreakerState = "Open"
+
+	// BreakerHalfOpen lets a single probe request through to decide whether
+	// to close or to open again.
+	BreakerHalfOpen BreakerState = "HalfOpen"
+)
+
+const (
+	// breakerThreshold is the number of consecutive failures that opens a
+	// breaker.
+	breakerThreshold = 5
+
+	// breakerCooldown is how long a breaker stays open before it lets a
+	// probe request through.
+	breakerCooldown = 30 * time.Second
+)
+
+// A Breaker stops requests to a Kafka Connect cluster once it fails
+// repeatedly, so that clients fail fast rather than waiting for requests to
+// a cluster that is down. Breakers are shared by all clients of a
+// ProviderConfig.
+type Breaker struct {
+	mu       sync.Mutex
+	state    BreakerState
+	failures int
+	openedAt time.Time
+	probing  bool
+
+	now func() time.Time
+}
+
+// NewBreaker returns a closed circuit breaker.
+func NewBreaker() *Breaker {
+	return &Breaker{state: BreakerClosed, now: time.Now}
+}
+
+// State of the breaker.
+func (b *Breaker) State() BreakerState {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if b.state == BreakerOpen && b.now().Sub(b.openedAt) >= breakerCooldown {
+		return BreakerHalfOpen
+	}
+	return b.state
+}
+
+// Err returns an UnavailableError while the breaker is open, i.e. until it
+// lets a probe request through.
+func (b *Breaker) Err() error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if b.state != BreakerOpen {
+		return nil
+	}
+	if retryAfter := breakerCooldown - b.now().Sub(b.openedAt); retryAfter > 0 {
+		return &UnavailableError{RetryAfter: retryAfter}
+	}
+	return nil
+}
+
+// allow returns an error if a request may not be sent.
+func (b *Breaker) allow() error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	switch b.state {
+	case BreakerClosed:
+		return nil
+	case BreakerOpen:
+		retryAfter := breakerCooldown - b.now().Sub(b.openedAt)
+		if retryAfter > 0 {
+			return &UnavailableError{RetryAfter: retryAfter}
+		}
+		b.state = BreakerHalfOpen
+	}
+
+	// Only one probe at a time is let through while half-open.
+	if b.probing {
+		return &UnavailableError{}
+	}
+	b.probing = true
+	return nil
+}
+
+// record the outcome of a request that was allowed.
+func (b *Breaker) record(ctx context.Context, err error) {
+	// Requests cancelled by the caller say nothing about the cluster.
+	if err != nil && ctx.Err() != nil {
+		b.mu.Lock()
+		b.probing = false
+		b.mu.Unlock()
+		return
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.probing = false
+
+	if !isClusterFailure(err) {
+		b.state, b.failures = BreakerClosed, 0
+		return
+	}
+
+	b.failures++
+	if b.state == BreakerHalfOpen || b.failures >= breakerThreshold {
+		b.state, b.openedAt = BreakerOpen, b.now()
+	}
+}
+
+// isClusterFailure returns true if the supplied error indicates that the
+// cluster could not serve a request. Rebalances are expected and do not
+// count as failures.
+func isClusterFailure(err error) bool {
+	if err == nil {
+		return false
+	}
+	var apiErr *APIError
+	if errors.As(err, &apiErr) && apiErr.StatusCode == http.StatusConflict {
+		return false
+	}
+	return isWorkerError(err)
+}
+
+// An UnavailableError is returned without sending a request while the circuit
+// breaker of a Kafka Connect cluster is open.
+type UnavailableError struct {
+	// RetryAfter is how long until the breaker lets a probe request through.
+	RetryAfter time.Duration
+}
+
+func (e *UnavailableError) Error() string {
+	if e.RetryAfter <= 0 {
+		return "Kafka Connect cluster unavailable: circuit breaker is probing the cluster"
+	}
+	return fmt.Sprintf("Kafka Connect cluster unavailable: circuit breaker is open, probing again in %s", e.RetryAfter.Round(time.Second))
+}
+
+// IsUnavailable returns true if the supplied error was returned because the
+// circuit breaker of a Kafka Connect cluster is open.
+func IsUnavailable(err error) bool {
+	var e *UnavailableError
+	return errors.As(err, &e)
+}
+
+// WithBreaker makes the client stop sending requests while the supplied
+// circuit breaker is open.
+func WithBreaker(b *Breaker) ClientOption {
+	return func(c *Client) {
+		c.breaker = b
+	}
+}
+
+var breakers = struct {
+	sync.Mutex
+	m map[string]*Breaker
+}{m: map[string]*Breaker{}}
+
+// BreakerFor returns the circuit breaker of the named ProviderConfig, or of
+// a URL named by OverrideBreaker.
+func BreakerFor(name string) *Breaker {
+	breakers.Lock()
+	defer breakers.Unlock()
+
+	b, ok := breakers.m[name]
+	if !ok {
+		b = NewBreaker()
+		breakers.m[name] = b
+	}
+	return b
+}
+
+// OverrideBreaker returns the name of the circuit breaker of the supplied URL,
+// which Connectors of the named ProviderConfig override its URLs with. The
+// breaker is kept per ProviderConfig so that it is forgotten with it.
+func OverrideBreaker(providerConfig, url string) string {
+	return providerConfig + " " + url
+}
+
+// BreakerStateOf returns the state of the circuit breaker of the named
+// ProviderConfig. Breakers of ProviderConfigs no client was created for yet
+// are closed.
+func BreakerStateOf(name string) BreakerState {
+	breakers.Lock()
+	b, ok := breakers.m[name]
+	breakers.Unlock()
+	if !ok {
+		return BreakerClosed
+	}
+	return b.State()
+}
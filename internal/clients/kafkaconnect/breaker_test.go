@@ -0,0 +1,86 @@
+package kafkaconnect
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestBreaker(t *testing.T) {
+	errDown := errors.New("connection refused")
+	errRebalance := &APIError{StatusCode: http.StatusConflict}
+
+	type step struct {
+		after time.Duration
+		err   error
+	}
+	type want struct {
+		state   BreakerState
+		blocked bool
+	}
+
+	cases := map[string]struct {
+		reason string
+		steps  []step
+		want   want
+	}{
+		"BelowThreshold": {
+			reason: "The breaker should stay closed below the failure threshold.",
+			steps:  []step{{err: errDown}, {err: errDown}, {err: errDown}, {err: errDown}},
+			want:   want{state: BreakerClosed},
+		},
+		"Reset": {
+			reason: "A success should reset the consecutive failures.",
+			steps:  []step{{err: errDown}, {err: errDown}, {err: errDown}, {err: errDown}, {}, {err: errDown}},
+			want:   want{state: BreakerClosed},
+		},
+		"Rebalance": {
+			reason: "Rebalances should not count as failures.",
+			steps:  []step{{err: errRebalance}, {err: errRebalance}, {err: errRebalance}, {err: errRebalance}, {err: errRebalance}},
+			want:   want{state: BreakerClosed},
+		},
+		"Open": {
+			reason: "The breaker should open at the failure threshold and block requests.",
+			steps:  []step{{err: errDown}, {err: errDown}, {err: errDown}, {err: errDown}, {err: errDown}},
+			want:   want{state: BreakerOpen, blocked: true},
+		},
+		"ProbeFailed": {
+			reason: "The breaker should open again if the probe after the cooldown fails.",
+			steps:  []step{{err: errDown}, {err: errDown}, {err: errDown}, {err: errDown}, {err: errDown}, {after: breakerCooldown, err: errDown}},
+			want:   want{state: BreakerOpen, blocked: true},
+		},
+		"ProbeSucceeded": {
+			reason: "The breaker should close if the probe after the cooldown succeeds.",
+			steps:  []step{{err: errDown}, {err: errDown}, {err: errDown}, {err: errDown}, {err: errDown}, {after: breakerCooldown}},
+			want:   want{state: BreakerClosed},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			now := time.Now()
+			b := NewBreaker()
+			b.now = func() time.Time { return now }
+
+			for i, s := range tc.steps {
+				now = now.Add(s.after)
+				if err := b.allow(); err != nil {
+					t.Fatalf("\n%s\nb.allow(): step %d: %v", tc.reason, i, err)
+				}
+				b.record(context.Background(), s.err)
+			}
+
+			if diff := cmp.Diff(tc.want.state, b.State()); diff != "" {
+				t.Errorf("\n%s\nb.State(): -want, +got:\n%s", tc.reason, diff)
+			}
+			err := b.allow()
+			if diff := cmp.Diff(tc.want.blocked, IsUnavailable(err)); diff != "" {
+				t.Errorf("\n%s\nb.allow(): -want blocked, +got:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
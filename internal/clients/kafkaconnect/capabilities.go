@@ -0,0 +1,197 @@
+package kafkaconnect
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// serverInfoTTL is how long a client caches the worker's server info.
+const serverInfoTTL = time.Minute
+
+// A Capability is a feature of the Kafka Connect REST API that is not
+// supported by all versions of Kafka Connect.
+type Capability string
+
+// Kafka Connect capabilities.
+const (
+	CapabilityExpand       Capability = "ExpandConnectors"
+	CapabilityRestartTasks Capability = "RestartTasks"
+	CapabilityStop         Capability = "Stop"
+	CapabilityGetOffsets   Capability = "GetOffsets"
+	CapabilityAlterOffsets Capability = "AlterOffsets"
+	CapabilityInitialState Capability = "InitialState"
+	CapabilityPatchConfig  Capability = "PatchConfig"
+	CapabilityHealth       Capability = "Health"
+)
+
+// minVersions are the Apache Kafka versions that introduced each capability.
+var minVersions = map[Capability]Version{
+	CapabilityExpand:       {Major: 2, Minor: 3},
+	CapabilityRestartTasks: {Major: 3, Minor: 0},
+	CapabilityStop:         {Major: 3, Minor: 5},
+	CapabilityGetOffsets:   {Major: 3, Minor: 5},
+	CapabilityAlterOffsets: {Major: 3, Minor: 6},
+	CapabilityInitialState: {Major: 3, Minor: 7},
+	CapabilityPatchConfig:  {Major: 3, Minor: 8},
+	CapabilityHealth:       {Major: 3, Minor: 9},
+}
+
+// A Version of Apache Kafka.
+type Version struct {
+	Major int
+	Minor int
+	Patch int
+}
+
+func (v Version) String() string {
+	return fmt.Sprintf("%d.%d.%d", v.Major, v.Minor, v.Patch)
+}
+
+// Less returns true if this version precedes the supplied one.
+func (v Version) Less(o Version) bool {
+	if v.Major != o.Major {
+		return v.Major < o.Major
+	}
+	if v.Minor != o.Minor {
+		return v.Minor < o.Minor
+	}
+	return v.Patch < o.Patch
+}
+
+// ParseVersion parses the version reported by a worker, e.g. 3.9.0 or
+// 3.7.1-SNAPSHOT. Confluent Platform versions, e.g. 7.7.0-ccs, are translated
+// to the Apache Kafka version they are based on.
+func ParseVersion(s string) (Version, error) {
+	base, qualifier, _ := strings.Cut(s, "-")
+	parts := strings.Split(base, ".")
+	if len(parts) < 2 || len(parts) > 3 {
+		return Version{}, fmt.Errorf("invalid Kafka Connect version %q", s)
+	}
+
+	n := make([]int, 3)
+	for i, p := range parts {
+		v, err := strconv.Atoi(p)
+		if err != nil {
+			return Version{}, fmt.Errorf("invalid Kafka Connect version %q", s)
+		}
+		n[i] = v
+	}
+	v := Version{Major: n[0], Minor: n[1], Patch: n[2]}
+
+	// Confluent Platform 5.x is based on Apache Kafka 2.0 to 2.5, 6.x on 2.6
+	// to 2.8, 7.x on 3.x and 8.x on 4.x.
+	switch qualifier {
+	case "ccs", "ce":
+		switch v.Major {
+		case 5:
+			v.Major = 2
+		case 6:
+			v.Major, v.Minor = 2, v.Minor+6
+		case 7, 8:
+			v.Major -= 4
+		}
+	}
+	return v, nil
+}
+
+// Capabilities of a Kafka Connect worker.
+type Capabilities struct {
+	// Version of the worker. It is nil if the worker reported a version that
+	// could not be parsed.
+	Version *Version
+}
+
+// Supports returns true if the worker supports the supplied capability.
+// Workers of unknown version are assumed to support all capabilities.
+func (c Capabilities) Supports(capability Capability) bool {
+	required, ok := minVersions[capability]
+	return !ok || c.Version == nil || !c.Version.Less(required)
+}
+
+// Require returns an error if the worker does not support the supplied
+// capability.
+func (c Capabilities) Require(capability Capability) error {
+	if c.Supports(capability) {
+		return nil
+	}
+	return &UnsupportedError{Capability: capability, Required: minVersions[capability], Actual: *c.Version}
+}
+
+// An UnsupportedError is returned if an operation is not supported by the
+// version of Kafka Connect a client talks to.
+type UnsupportedError struct {
+	Capability Capability
+	Required   Version
+	Actual     Version
+}
+
+func (e *UnsupportedError) Error() string {
+	return fmt.Sprintf("%s requires Kafka Connect >= %d.%d, worker runs %s", e.Capability, e.Required.Major, e.Required.Minor, e.Actual)
+}
+
+// IsUnsupported returns true if the supplied error indicates that an
+// operation is not supported by the version of Kafka Connect.
+func IsUnsupported(err error) bool {
+	var e *UnsupportedError
+	return errors.As(err, &e)
+}
+
+// Capabilities returns the capabilities of the worker. The worker's version
+// is cached by the client.
+func (c *Client) Capabilities(ctx context.Context) (Capabilities, error) {
+	info, err := c.cachedServerInfo(ctx)
+	if err != nil {
+		return Capabilities{}, err
+	}
+	v, err := ParseVersion(info.Version)
+	if err != nil {
+		return Capabilities{}, nil
+	}
+	return Capabilities{Version: &v}, nil
+}
+
+// Require returns an error if the worker does not support the supplied
+// capability.
+func (c *Client) Require(ctx context.Context, capability Capability) error {
+	caps, err := c.Capabilities(ctx)
+	if err != nil {
+		return fmt.Errorf("cannot determine Kafka Connect capabilities: %w", err)
+	}
+	return caps.Require(capability)
+}
+
+// serverInfoCache caches the server info of the worker a client talks to.
+type serverInfoCache struct {
+	mu      sync.Mutex
+	info    *ServerInfo
+	fetched time.Time
+}
+
+func (s *serverInfoCache) load() *ServerInfo {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.info == nil || time.Since(s.fetched) >= serverInfoTTL {
+		return nil
+	}
+	return s.info
+}
+
+func (s *serverInfoCache) store(info *ServerInfo) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.info, s.fetched = info, time.Now()
+}
+
+// cachedServerInfo returns the server info of the worker, fetching it if it
+// was not fetched within the TTL.
+func (c *Client) cachedServerInfo(ctx context.Context) (*ServerInfo, error) {
+	if info := c.info.load(); info != nil {
+		return info, nil
+	}
+	return c.GetServerInfo(ctx)
+}
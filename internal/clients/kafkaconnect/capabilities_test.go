@@ -0,0 +1,184 @@
+package kafkaconnect
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestParseVersion(t *testing.T) {
+	type want struct {
+		v   Version
+		err bool
+	}
+
+	cases := map[string]struct {
+		reason  string
+		version string
+		want    want
+	}{
+		"Apache": {
+			reason:  "Apache Kafka versions should be parsed as is.",
+			version: "3.9.0",
+			want:    want{v: Version{Major: 3, Minor: 9}},
+		},
+		"Snapshot": {
+			reason:  "Qualifiers should be ignored.",
+			version: "3.7.1-SNAPSHOT",
+			want:    want{v: Version{Major: 3, Minor: 7, Patch: 1}},
+		},
+		"ConfluentPlatform": {
+			reason:  "Confluent Platform versions should be translated to Apache Kafka versions.",
+			version: "7.7.0-ccs",
+			want:    want{v: Version{Major: 3, Minor: 7}},
+		},
+		"ConfluentPlatform5": {
+			reason:  "Confluent Platform 5 versions should be translated to Apache Kafka 2 versions.",
+			version: "5.5.0-ccs",
+			want:    want{v: Version{Major: 2, Minor: 5}},
+		},
+		"ConfluentPlatform6": {
+			reason:  "Confluent Platform 6 versions should be translated to Apache Kafka 2 versions.",
+			version: "6.2.1-ce",
+			want:    want{v: Version{Major: 2, Minor: 8, Patch: 1}},
+		},
+		"Invalid": {
+			reason:  "Versions that are not dotted numbers should be rejected.",
+			version: "trunk",
+			want:    want{err: true},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got, err := ParseVersion(tc.version)
+			if (err != nil) != tc.want.err {
+				t.Errorf("\n%s\nParseVersion(...): want error %t, got %v", tc.reason, tc.want.err, err)
+			}
+			if diff := cmp.Diff(tc.want.v, got); diff != "" {
+				t.Errorf("\n%s\nParseVersion(...): -want, +got:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestCapabilitiesSupports(t *testing.T) {
+	cases := map[string]struct {
+		reason     string
+		capability Capability
+		version    *Version
+		want       bool
+	}{
+		"RestartTasks": {
+			reason:     "Restarting tasks with a connector should require Kafka Connect 3.0.",
+			capability: CapabilityRestartTasks,
+			version:    &Version{Major: 2, Minor: 8, Patch: 2},
+		},
+		"Stop": {
+			reason:     "Stopping connectors should require Kafka Connect 3.5.",
+			capability: CapabilityStop,
+			version:    &Version{Major: 3, Minor: 5},
+			want:       true,
+		},
+		"GetOffsets": {
+			reason:     "Reading offsets should require Kafka Connect 3.5.",
+			capability: CapabilityGetOffsets,
+			version:    &Version{Major: 3, Minor: 4, Patch: 1},
+		},
+		"AlterOffsets": {
+			reason:     "Altering offsets should require Kafka Connect 3.6.",
+			capability: CapabilityAlterOffsets,
+			version:    &Version{Major: 3, Minor: 5, Patch: 2},
+		},
+		"InitialState": {
+			reason:     "Creating connectors in an initial state should require Kafka Connect 3.7.",
+			capability: CapabilityInitialState,
+			version:    &Version{Major: 3, Minor: 7},
+			want:       true,
+		},
+		"PatchConfig": {
+			reason:     "Patching connector configs should require Kafka Connect 3.8.",
+			capability: CapabilityPatchConfig,
+			version:    &Version{Major: 3, Minor: 7, Patch: 1},
+		},
+		"Health": {
+			reason:     "The health endpoint should require Kafka Connect 3.9.",
+			capability: CapabilityHealth,
+			version:    &Version{Major: 3, Minor: 9},
+			want:       true,
+		},
+		"UnknownVersion": {
+			reason:     "Workers of unknown version should be assumed to support all capabilities.",
+			capability: CapabilityPatchConfig,
+			want:       true,
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got := Capabilities{Version: tc.version}.Supports(tc.capability)
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("\n%s\nSupports(...): -want, +got:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestClientRequire(t *testing.T) {
+	cases := map[string]struct {
+		reason      string
+		version     string
+		unsupported bool
+		calls       int32
+	}{
+		"Supported": {
+			reason:  "Operations supported by the worker's version should be sent.",
+			version: "3.9.0",
+			calls:   1,
+		},
+		"Unsupported": {
+			reason:      "Operations not supported by the worker's version should fail without being sent.",
+			version:     "3.6.2",
+			unsupported: true,
+		},
+		"UnknownVersion": {
+			reason:  "Operations should be sent if the worker's version is unknown.",
+			version: "trunk",
+			calls:   1,
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			var roots, calls atomic.Int32
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if r.URL.Path == "/" {
+					roots.Add(1)
+					_, _ = w.Write([]byte(`{"version":"` + tc.version + `"}`))
+					return
+				}
+				calls.Add(1)
+				_, _ = w.Write([]byte(`{"status":"healthy"}`))
+			}))
+			defer srv.Close()
+
+			c := NewClient(srv.URL)
+			for range 2 {
+				_, err := c.GetHealth(context.Background())
+				if diff := cmp.Diff(tc.unsupported, IsUnsupported(err)); diff != "" {
+					t.Errorf("\n%s\nc.GetHealth(...): -want unsupported, +got:\n%s\nerror: %v", tc.reason, diff, err)
+				}
+			}
+			if diff := cmp.Diff(tc.calls*2, calls.Load()); diff != "" {
+				t.Errorf("\n%s\nc.GetHealth(...): -want calls, +got calls:\n%s", tc.reason, diff)
+			}
+			if diff := cmp.Diff(int32(1), roots.Load()); diff != "" {
+				t.Errorf("\n%s\nc.GetHealth(...): want the version to be cached, -want, +got:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
@@ -4,10 +4,35 @@ import (
     "bytes"
     "context"
     "encoding/json"
+    "errors"
     "fmt"
     "io"
     "net/http"
+    "net/url"
+    "strconv"
+    "strings"
     "time"
+
+    "go.opentelemetry.io/otel"
+    "go.opentelemetry.io/otel/propagation"
+    semconv "go.opentelemetry.io/otel/semconv/v1.26.0"
+    "go.opentelemetry.io/otel/trace"
+
+    "github.com/crossplane/provider-kafkaconnect/internal/metrics"
+    "github.com/crossplane/provider-kafkaconnect/internal/tracing"
+)
+
+// Endpoint templates of the Kafka Connect REST API. Placeholders are replaced
+// with path escaped arguments in order of appearance.
+const (
+    endpointRoot            = "/"
+    endpointHealth          = "/health"
+    endpointConnectors      = "/connectors"
+    endpointConnector       = "/connectors/{name}"
+    endpointConnectorConfig = "/connectors/{name}/config"
+    endpointConnectorStatus = "/connectors/{name}/status"
+    endpointConnectorRestart = "/connectors/{name}/restart"
+    endpointTaskRestart     = "/connectors/{name}/tasks/{task}/restart"
 )
 
 // Client is a Kafka Connect API client
@@ -16,6 +41,26 @@ type Client struct {
     httpClient *http.Client
     username   string
     password   string
+
+    // providerConfig is the name of the ProviderConfig this client was
+    // created for. It is used to label metrics.
+    providerConfig string
+
+    retry RetryPolicy
+
+    // endpoints overrides baseURL with the active endpoint of a set of
+    // workers, if set.
+    endpoints *EndpointSet
+
+    // breaker stops requests while the cluster is down, if set.
+    breaker *Breaker
+
+    // limiter limits the concurrency and rate of requests, if set.
+    limiter *Limiter
+
+    // expected identity of the cluster, verified before mutating requests.
+    expected ClusterIdentity
+    info     serverInfoCache
 }
 
 // NewClient creates a new Kafka Connect client
@@ -25,6 +70,7 @@ func NewClient(baseURL string, options ...ClientOption) *Client {
         httpClient: &http.Client{
             Timeout: 30 * time.Second,
         },
+        retry: DefaultRetryPolicy,
     }
     
     for _, opt := range options {
@@ -52,6 +98,39 @@ func WithBasicAuth(username, password string) ClientOption {
     }
 }
 
+// WithProviderConfig sets the name of the ProviderConfig the client was
+// created for
+func WithProviderConfig(name string) ClientOption {
+    return func(c *Client) {
+        c.providerConfig = name
+    }
+}
+
+// APIError is returned when Kafka Connect answers with a non-2xx status code
+type APIError struct {
+    StatusCode int    `json:"error_code"`
+    Message    string `json:"message"`
+}
+
+func (e *APIError) Error() string {
+    return fmt.Sprintf("unexpected status code %d: %s", e.StatusCode, e.Message)
+}
+
+// IsNotFound returns true if the supplied error indicates that the requested
+// Kafka Connect resource does not exist
+func IsNotFound(err error) bool {
+    var apiErr *APIError
+    return errors.As(err, &apiErr) && apiErr.StatusCode == http.StatusNotFound
+}
+
+// IsAlreadyExists returns true if the supplied error indicates that a
+// connector could not be created because one of the same name exists. Kafka
+// Connect answers 409 both in that case and while a rebalance is in progress.
+func IsAlreadyExists(err error) bool {
+    var apiErr *APIError
+    return errors.As(err, &apiErr) && apiErr.StatusCode == http.StatusConflict && strings.Contains(apiErr.Message, "already exists")
+}
+
 // ConnectorConfig represents a connector configuration
 type ConnectorConfig struct {
     Name   string            `json:"name"`
@@ -63,7 +142,10 @@ type ConnectorStatus struct {
     Name      string                 `json:"name"`
     Connector ConnectorState         `json:"connector"`
     Tasks     []TaskState           `json:"tasks"`
-    Type      string                 `json:"type"`
+
+    // Type of the connector, i.e. source or sink. Some workers do not
+    // report it.
+    Type string `json:"type,omitempty"`
 }
 
 // ConnectorState represents the state of a connector
@@ -88,22 +170,43 @@ func (c *Client) CreateConnector(ctx context.Context, config ConnectorConfig) (*
         return nil, fmt.Errorf("failed to marshal connector config: %w", err)
     }
     
-    req, err := c.newRequest(ctx, http.MethodPost, "/connectors", bytes.NewReader(body))
+    req, err := c.newRequest(ctx, http.MethodPost, endpointConnectors, bytes.NewReader(body))
     if err != nil {
         return nil, err
     }
     
-    var info ConnectorInfo
-    if err := c.doRequest(req, &info); err != nil {
+    if err := c.verifyCluster(req); err != nil {
         return nil, fmt.Errorf("failed to create connector: %w", err)
     }
     
-    return &info, nil
+    // Creating a connector is not idempotent. Before a failed create is
+    // retried we check whether it succeeded anyway, e.g. because only the
+    // response was lost or a rebalance finished after the connector was
+    // written to the config topic.
+    var info ConnectorInfo
+    for attempt := 1; ; attempt++ {
+        err = c.do(req, &info)
+        if err == nil {
+            return &info, nil
+        }
+        if attempt >= c.retry.MaxAttempts || !IsRetryable(err) {
+            return nil, fmt.Errorf("failed to create connector: %w", err)
+        }
+        if err := c.retry.wait(ctx, attempt); err != nil {
+            return nil, fmt.Errorf("failed to create connector: %w", err)
+        }
+        if existing, gerr := c.GetConnector(ctx, config.Name); gerr == nil {
+            return existing, nil
+        }
+        if req, err = rewind(req); err != nil {
+            return nil, err
+        }
+    }
 }
 
 // GetConnector gets a connector by name
 func (c *Client) GetConnector(ctx context.Context, name string) (*ConnectorInfo, error) {
-    req, err := c.newRequest(ctx, http.MethodGet, fmt.Sprintf("/connectors/%s", name), nil)
+    req, err := c.newRequest(ctx, http.MethodGet, endpointConnector, nil, name)
     if err != nil {
         return nil, err
     }
@@ -123,7 +226,7 @@ func (c *Client) UpdateConnector(ctx context.Context, name string, config map[st
         return nil, fmt.Errorf("failed to marshal connector config: %w", err)
     }
     
-    req, err := c.newRequest(ctx, http.MethodPut, fmt.Sprintf("/connectors/%s/config", name), bytes.NewReader(body))
+    req, err := c.newRequest(ctx, http.MethodPut, endpointConnectorConfig, bytes.NewReader(body), name)
     if err != nil {
         return nil, err
     }
@@ -138,7 +241,7 @@ func (c *Client) UpdateConnector(ctx context.Context, name string, config map[st
 
 // DeleteConnector deletes a connector
 func (c *Client) DeleteConnector(ctx context.Context, name string) error {
-    req, err := c.newRequest(ctx, http.MethodDelete, fmt.Sprintf("/connectors/%s", name), nil)
+    req, err := c.newRequest(ctx, http.MethodDelete, endpointConnector, nil, name)
     if err != nil {
         return err
     }
@@ -152,7 +255,7 @@ func (c *Client) DeleteConnector(ctx context.Context, name string) error {
 
 // GetConnectorStatus gets the status of a connector
 func (c *Client) GetConnectorStatus(ctx context.Context, name string) (*ConnectorStatus, error) {
-    req, err := c.newRequest(ctx, http.MethodGet, fmt.Sprintf("/connectors/%s/status", name), nil)
+    req, err := c.newRequest(ctx, http.MethodGet, endpointConnectorStatus, nil, name)
     if err != nil {
         return nil, err
     }
@@ -165,24 +268,170 @@ func (c *Client) GetConnectorStatus(ctx context.Context, name string) (*Connecto
     return &status, nil
 }
 
+// RestartConnector restarts the named connector. With includeTasks its tasks
+// are restarted too, and with onlyFailed only the failed instances of the
+// connector and its tasks are restarted. Both are not supported before Kafka
+// 3.0, where tasks must be restarted with RestartTask.
+func (c *Client) RestartConnector(ctx context.Context, name string, includeTasks, onlyFailed bool) error {
+    if includeTasks || onlyFailed {
+        if err := c.Require(ctx, CapabilityRestartTasks); err != nil {
+            return fmt.Errorf("failed to restart connector: %w", err)
+        }
+    }
+    
+    req, err := c.newRequest(ctx, http.MethodPost, endpointConnectorRestart, nil, name)
+    if err != nil {
+        return err
+    }
+    req.URL.RawQuery = url.Values{
+        "includeTasks": {strconv.FormatBool(includeTasks)},
+        "onlyFailed":   {strconv.FormatBool(onlyFailed)},
+    }.Encode()
+    
+    if err := c.doRequest(req, nil); err != nil {
+        return fmt.Errorf("failed to restart connector: %w", err)
+    }
+    
+    return nil
+}
+
+// RestartTask restarts a task of the named connector
+func (c *Client) RestartTask(ctx context.Context, name string, task int) error {
+    req, err := c.newRequest(ctx, http.MethodPost, endpointTaskRestart, nil, name, strconv.Itoa(task))
+    if err != nil {
+        return err
+    }
+    
+    if err := c.doRequest(req, nil); err != nil {
+        return fmt.Errorf("failed to restart task: %w", err)
+    }
+    
+    return nil
+}
+
+// ExpandedConnector is the info and status of a connector as returned by
+// listing connectors with expand=info and expand=status
+type ExpandedConnector struct {
+    Info   *ConnectorInfo   `json:"info,omitempty"`
+    Status *ConnectorStatus `json:"status,omitempty"`
+}
+
+// ListConnectorsExpanded lists all connectors with their info and status,
+// keyed by connector name
+func (c *Client) ListConnectorsExpanded(ctx context.Context) (map[string]ExpandedConnector, error) {
+    if err := c.Require(ctx, CapabilityExpand); err != nil {
+        return nil, fmt.Errorf("failed to list connectors: %w", err)
+    }
+    
+    req, err := c.newRequest(ctx, http.MethodGet, endpointConnectors, nil)
+    if err != nil {
+        return nil, err
+    }
+    req.URL.RawQuery = url.Values{"expand": {"status", "info"}}.Encode()
+    
+    connectors := map[string]ExpandedConnector{}
+    if err := c.doRequest(req, &connectors); err != nil {
+        return nil, fmt.Errorf("failed to list connectors: %w", err)
+    }
+    
+    return connectors, nil
+}
+
+// ServerInfo represents the information a worker returns on its root endpoint
+type ServerInfo struct {
+    Version        string `json:"version"`
+    Commit         string `json:"commit"`
+    KafkaClusterID string `json:"kafka_cluster_id"`
+
+    // GroupID of the Kafka Connect cluster. It is not reported by Apache
+    // Kafka workers.
+    GroupID string `json:"group_id,omitempty"`
+}
+
+// CloseIdleConnections closes the idle connections of the client.
+func (c *Client) CloseIdleConnections() {
+    c.httpClient.CloseIdleConnections()
+}
+
+// GetServerInfo gets the version of the worker and the ID of the Kafka cluster
+// it is connected to
+func (c *Client) GetServerInfo(ctx context.Context) (*ServerInfo, error) {
+    req, err := c.newRequest(ctx, http.MethodGet, endpointRoot, nil)
+    if err != nil {
+        return nil, err
+    }
+    
+    var info ServerInfo
+    if err := c.doRequest(req, &info); err != nil {
+        return nil, fmt.Errorf("failed to get server info: %w", err)
+    }
+    
+    c.info.store(&info)
+    return &info, nil
+}
+
+// HealthStatus represents the health of a worker
+type HealthStatus struct {
+    Status  string `json:"status"`
+    Message string `json:"message"`
+}
+
+// GetHealth gets the health of the worker. Workers answer 503 while they are
+// starting or unhealthy. The endpoint is not supported before Kafka 3.9.
+func (c *Client) GetHealth(ctx context.Context) (*HealthStatus, error) {
+    if err := c.Require(ctx, CapabilityHealth); err != nil {
+        return nil, fmt.Errorf("failed to get health: %w", err)
+    }
+    
+    req, err := c.newRequest(ctx, http.MethodGet, endpointHealth, nil)
+    if err != nil {
+        return nil, err
+    }
+    
+    var health HealthStatus
+    if err := c.doRequest(req, &health); err != nil {
+        return nil, fmt.Errorf("failed to get health: %w", err)
+    }
+    
+    return &health, nil
+}
+
 // ConnectorInfo represents connector information
 type ConnectorInfo struct {
     Name   string            `json:"name"`
     Config map[string]string `json:"config"`
     Tasks  []TaskInfo        `json:"tasks"`
-    Type   string            `json:"type"`
+
+    // Type of the connector, i.e. source or sink. Some workers do not
+    // report it.
+    Type string `json:"type,omitempty"`
 }
 
 // TaskInfo represents task information
 type TaskInfo struct {
     Connector string            `json:"connector"`
     Task      int               `json:"task"`
-    Config    map[string]string `json:"config"`
+
+    // Config of the task. It is only returned by the tasks endpoint, not
+    // with the info of a connector.
+    Config map[string]string `json:"config,omitempty"`
 }
 
-func (c *Client) newRequest(ctx context.Context, method, path string, body io.Reader) (*http.Request, error) {
-    url := c.baseURL + path
-    req, err := http.NewRequestWithContext(ctx, method, url, body)
+type requestKey struct{}
+
+// requestInfo is stored in the context of each request.
+type requestInfo struct {
+    // endpoint template of the request.
+    endpoint string
+
+    // path of the request relative to the base URL of the worker.
+    path string
+}
+
+func (c *Client) newRequest(ctx context.Context, method, endpoint string, body io.Reader, args ...string) (*http.Request, error) {
+    path := expandEndpoint(endpoint, args...)
+    ctx = context.WithValue(ctx, requestKey{}, requestInfo{endpoint: endpoint, path: path})
+    req, err := http.NewRequestWithContext(ctx, method, c.baseURL+path, body)
     if err != nil {
         return nil, err
     }
@@ -197,16 +446,96 @@ func (c *Client) newRequest(ctx context.Context, method, path string, body io.Re
     return req, nil
 }
 
+// doRequest sends the supplied request, retrying idempotent requests that
+// failed transiently according to the client's retry policy.
 func (c *Client) doRequest(req *http.Request, v interface{}) error {
+    if err := c.verifyCluster(req); err != nil {
+        return err
+    }
+
+    attempts := 1
+    if isIdempotent(req.Method) {
+        attempts = c.retry.MaxAttempts
+    }
+
+    for attempt := 1; ; attempt++ {
+        err := c.do(req, v)
+        if err == nil || attempt >= attempts || !IsRetryable(err) {
+            return err
+        }
+        if err := c.retry.wait(req.Context(), attempt); err != nil {
+            return err
+        }
+        if req, err = rewind(req); err != nil {
+            return err
+        }
+    }
+}
+
+func (c *Client) do(req *http.Request, v interface{}) (err error) {
+    info, _ := req.Context().Value(requestKey{}).(requestInfo)
+    endpoint := info.endpoint
+    
+    if c.breaker != nil {
+        if err := c.breaker.allow(); err != nil {
+            return err
+        }
+        defer func() { c.breaker.record(req.Context(), err) }()
+    }
+    
+    if c.limiter != nil {
+        queued := time.Now()
+        release, err := c.limiter.wait(req.Context())
+        metrics.ClientRequestQueueDuration.WithLabelValues(c.providerConfig).Observe(time.Since(queued).Seconds())
+        if err != nil {
+            return err
+        }
+        defer release()
+    }
+
+    if c.endpoints != nil {
+        base := c.endpoints.Active()
+        if req, err = retarget(req, base+info.path); err != nil {
+            return err
+        }
+        defer func() { c.endpoints.Report(base, err) }()
+    }
+
+    ctx, span := tracing.Start(req.Context(), req.Method+" "+endpoint,
+        trace.WithSpanKind(trace.SpanKindClient),
+        trace.WithAttributes(
+            semconv.HTTPRequestMethodKey.String(req.Method),
+            semconv.HTTPRoute(endpoint),
+            semconv.URLFull(req.URL.String()),
+            tracing.AttributeProviderConfig.String(c.providerConfig),
+        ))
+    defer func() { tracing.End(span, err) }()
+    req = req.WithContext(ctx)
+    otel.GetTextMapPropagator().Inject(ctx, propagation.HeaderCarrier(req.Header))
+
+    metrics.ClientRequests.WithLabelValues(c.providerConfig, req.Method, endpoint).Inc()
+    start := time.Now()
+    defer func() {
+        metrics.ClientRequestDuration.WithLabelValues(c.providerConfig, req.Method, endpoint).Observe(time.Since(start).Seconds())
+    }()
+
     resp, err := c.httpClient.Do(req)
     if err != nil {
+        metrics.ClientRequestErrors.WithLabelValues(c.providerConfig, req.Method, endpoint, metrics.CodeTransportError).Inc()
         return err
     }
     defer resp.Body.Close()
+    span.SetAttributes(semconv.HTTPResponseStatusCode(resp.StatusCode))
     
     if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+        metrics.ClientRequestErrors.WithLabelValues(c.providerConfig, req.Method, endpoint, strconv.Itoa(resp.StatusCode)).Inc()
         body, _ := io.ReadAll(resp.Body)
-        return fmt.Errorf("unexpected status code %d: %s", resp.StatusCode, string(body))
+        apiErr := &APIError{StatusCode: resp.StatusCode}
+        if json.Unmarshal(body, apiErr) != nil || apiErr.Message == "" {
+            apiErr.Message = string(body)
+        }
+        apiErr.StatusCode = resp.StatusCode
+        return apiErr
     }
     
     if v != nil && resp.StatusCode != http.StatusNoContent {
@@ -217,3 +546,22 @@ func (c *Client) doRequest(req *http.Request, v interface{}) error {
     
     return nil
 }
+
+// expandEndpoint replaces the placeholders of the supplied endpoint template
+// with the supplied arguments.
+func expandEndpoint(endpoint string, args ...string) string {
+    var b strings.Builder
+    rest := endpoint
+    for _, arg := range args {
+        i := strings.IndexByte(rest, '{')
+        j := strings.IndexByte(rest, '}')
+        if i < 0 || j < i {
+            break
+        }
+        b.WriteString(rest[:i])
+        b.WriteString(url.PathEscape(arg))
+        rest = rest[j+1:]
+    }
+    b.WriteString(rest)
+    return b.String()
+}
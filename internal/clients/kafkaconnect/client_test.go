@@ -0,0 +1,106 @@
+package kafkaconnect
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+
+	"github.com/crossplane/provider-kafkaconnect/internal/metrics"
+	"github.com/crossplane/provider-kafkaconnect/internal/tracing"
+)
+
+func TestExpandEndpoint(t *testing.T) {
+	cases := map[string]struct {
+		endpoint string
+		args     []string
+		want     string
+	}{
+		"NoPlaceholders": {
+			endpoint: endpointConnectors,
+			want:     "/connectors",
+		},
+		"EscapesArguments": {
+			endpoint: endpointConnectorStatus,
+			args:     []string{"my connector/1"},
+			want:     "/connectors/my%20connector%2F1/status",
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got := expandEndpoint(tc.endpoint, tc.args...)
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("expandEndpoint(...): -want, +got:\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestClientMetrics(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+		_, _ = w.Write([]byte(`{"error_code":404,"message":"Connector a not found"}`))
+	}))
+	defer srv.Close()
+
+	c := NewClient(srv.URL, WithProviderConfig("metrics-test"))
+	_, err := c.GetConnectorStatus(context.Background(), "a")
+	if !IsNotFound(err) {
+		t.Fatalf("GetConnectorStatus(...): want not found error, got %v", err)
+	}
+
+	if got := testutil.ToFloat64(metrics.ClientRequests.WithLabelValues("metrics-test", http.MethodGet, endpointConnectorStatus)); got != 1 {
+		t.Errorf("ClientRequests: want 1, got %v", got)
+	}
+	if got := testutil.ToFloat64(metrics.ClientRequestErrors.WithLabelValues("metrics-test", http.MethodGet, endpointConnectorStatus, "404")); got != 1 {
+		t.Errorf("ClientRequestErrors: want 1, got %v", got)
+	}
+}
+
+func TestClientTracing(t *testing.T) {
+	gtp, gprop := otel.GetTracerProvider(), otel.GetTextMapPropagator()
+	defer func() {
+		otel.SetTracerProvider(gtp)
+		otel.SetTextMapPropagator(gprop)
+	}()
+
+	exp := tracetest.NewInMemoryExporter()
+	tp := tracing.Install(exp, tracing.Options{SampleRatio: 1})
+
+	var traceparent string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		traceparent = r.Header.Get("traceparent")
+		_, _ = w.Write([]byte(`{"name":"a","connector":{"state":"RUNNING","worker_id":"w1"},"tasks":[]}`))
+	}))
+	defer srv.Close()
+
+	ctx, parent := tracing.Start(context.Background(), "Observe")
+	if _, err := NewClient(srv.URL).GetConnectorStatus(ctx, "a"); err != nil {
+		t.Fatalf("GetConnectorStatus(...): %v", err)
+	}
+	parent.End()
+	if err := tp.ForceFlush(context.Background()); err != nil {
+		t.Fatalf("ForceFlush(...): %v", err)
+	}
+
+	spans := exp.GetSpans()
+	if len(spans) != 2 {
+		t.Fatalf("want 2 spans, got %d", len(spans))
+	}
+	child := spans[0]
+	if diff := cmp.Diff("GET "+endpointConnectorStatus, child.Name); diff != "" {
+		t.Errorf("span name: -want, +got:\n%s", diff)
+	}
+	if child.Parent.SpanID() != parent.SpanContext().SpanID() {
+		t.Errorf("want HTTP span to be a child of the Observe span")
+	}
+	if traceparent == "" {
+		t.Errorf("want traceparent header to be propagated")
+	}
+}
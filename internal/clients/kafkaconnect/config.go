@@ -0,0 +1,136 @@
+package kafkaconnect
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"slices"
+	"strings"
+
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/crossplane/provider-kafkaconnect/apis/v1alpha1"
+)
+
+// Credentials are read as JSON from the source configured in a
+// ProviderConfig, e.g. {"username": "admin", "password": "secret"}.
+type Credentials struct {
+	Username string `json:"username,omitempty"`
+	Password string `json:"password,omitempty"`
+}
+
+// NewFromProviderConfig creates a Kafka Connect client for the cluster
+// described by the supplied ProviderConfig, authenticating with the supplied
+// raw credentials. Empty credentials disable authentication. The workers of
+// a ProviderConfig with a ServiceRef must be supplied WithWorkers.
+func NewFromProviderConfig(pc *v1alpha1.ProviderConfig, creds []byte, options ...ClientOption) (*Client, error) {
+	hc, err := newHTTPClient(pc.Spec.TLS, pc.Spec.Transport)
+	if err != nil {
+		return nil, err
+	}
+
+	opts := []ClientOption{
+		WithHTTPClient(hc),
+		WithProviderConfig(pc.GetName()),
+		WithWorkers(pc.Endpoints()...),
+		WithBreaker(BreakerFor(pc.GetName())),
+		WithLimiter(LimiterFor(pc)),
+		WithExpectedCluster(ClusterIdentity{
+			KafkaClusterID: pc.Spec.ExpectedKafkaClusterID,
+			ConnectGroup:   pc.Spec.ExpectedConnectGroup,
+		}),
+	}
+	if len(creds) > 0 {
+		c := Credentials{}
+		if err := json.Unmarshal(creds, &c); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal credentials: %w", err)
+		}
+		opts = append(opts, WithBasicAuth(c.Username, c.Password))
+	}
+
+	c := NewClient("", append(opts, options...)...)
+	if c.baseURL == "" {
+		return nil, errors.New("no Kafka Connect URL configured")
+	}
+	return c, nil
+}
+
+// ResolveWorkers returns the worker URLs of a ProviderConfig with a
+// ServiceRef, to be supplied WithWorkers. It returns nil for ProviderConfigs
+// with literal URLs.
+func ResolveWorkers(ctx context.Context, kube client.Reader, pc *v1alpha1.ProviderConfig) ([]string, error) {
+	if pc.Spec.ServiceRef == nil {
+		return nil, nil
+	}
+	return ResolveServiceRef(ctx, kube, pc.Spec.ServiceRef)
+}
+
+// WithURL makes the client send all requests to the supplied URL rather than
+// to the workers of its ProviderConfig. The identity the ProviderConfig
+// expects is still verified, so that the URL cannot point at another cluster.
+// It must be supplied after WithProviderConfig, which NewFromProviderConfig
+// does for callers.
+func WithURL(u string) ClientOption {
+	return func(c *Client) {
+		c.baseURL = u
+		c.endpoints = nil
+		c.breaker = BreakerFor(OverrideBreaker(c.providerConfig, u))
+	}
+}
+
+// AllowedURL returns an error unless the supplied ProviderConfig allows
+// Connectors to override its URLs with the supplied one.
+func AllowedURL(pc *v1alpha1.ProviderConfig, raw string) error {
+	u, err := url.Parse(raw)
+	if err != nil {
+		return fmt.Errorf("invalid Kafka Connect URL: %w", err)
+	}
+	if u.Scheme != "http" && u.Scheme != "https" {
+		return fmt.Errorf("invalid Kafka Connect URL %q: scheme must be http or https", raw)
+	}
+	if !slices.ContainsFunc(pc.Spec.AllowedConnectorURLHosts, func(h string) bool { return hostMatches(h, u) }) {
+		return fmt.Errorf("Kafka Connect URL %q is not on a host allowed by ProviderConfig %q", raw, pc.GetName())
+	}
+	return nil
+}
+
+// hostMatches returns true if the supplied URL is on the supplied allowed
+// host, which may include a port or start with a *. wildcard. Host names are
+// compared case-insensitively.
+func hostMatches(allowed string, u *url.URL) bool {
+	host := u.Hostname()
+	if strings.Contains(allowed, ":") {
+		host = u.Host
+	}
+	host, allowed = strings.ToLower(host), strings.ToLower(allowed)
+	if suffix, ok := strings.CutPrefix(allowed, "*"); ok && strings.HasPrefix(suffix, ".") {
+		return strings.HasSuffix(host, suffix) && len(host) > len(suffix)
+	}
+	return host == allowed
+}
+
+func newHTTPClient(cfg *v1alpha1.TLSConfig, tc *v1alpha1.TransportConfig) (*http.Client, error) {
+	t := http.DefaultTransport.(*http.Transport).Clone()
+	if cfg != nil {
+		c := &tls.Config{
+			MinVersion:         tls.VersionTLS12,
+			InsecureSkipVerify: cfg.InsecureSkipVerify, //nolint:gosec // Explicitly requested by the ProviderConfig.
+		}
+		if len(cfg.CABundle) > 0 {
+			pool := x509.NewCertPool()
+			if !pool.AppendCertsFromPEM(cfg.CABundle) {
+				return nil, errors.New("failed to parse CA bundle")
+			}
+			c.RootCAs = pool
+		}
+		t.TLSClientConfig = c
+	}
+
+	hc := &http.Client{Transport: t, Timeout: defaultTimeout}
+	return hc, configureTransport(hc, t, tc)
+}
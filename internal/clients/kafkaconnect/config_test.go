@@ -0,0 +1,64 @@
+package kafkaconnect
+
+import (
+	"testing"
+
+	"github.com/crossplane/provider-kafkaconnect/apis/v1alpha1"
+)
+
+func TestAllowedURL(t *testing.T) {
+	cases := map[string]struct {
+		reason  string
+		allowed []string
+		url     string
+		want    bool
+	}{
+		"NoneAllowed": {
+			reason: "URLs should not be overridden unless the ProviderConfig allows hosts.",
+			url:    "http://connect.example.com:8083",
+		},
+		"Host": {
+			reason:  "URLs on an allowed host should be allowed on any port.",
+			allowed: []string{"connect.example.com"},
+			url:     "http://connect.example.com:8083",
+			want:    true,
+		},
+		"HostAndPort": {
+			reason:  "URLs on an allowed host but another port should not be allowed.",
+			allowed: []string{"connect.example.com:8083"},
+			url:     "http://connect.example.com:9090",
+		},
+		"Wildcard": {
+			reason:  "Wildcards should match subdomains.",
+			allowed: []string{"*.example.com"},
+			url:     "https://connect.prod.example.com",
+			want:    true,
+		},
+		"WildcardApex": {
+			reason:  "Wildcards should not match the domain itself.",
+			allowed: []string{"*.example.com"},
+			url:     "https://example.com",
+		},
+		"WildcardCase": {
+			reason:  "Wildcards should match subdomains regardless of case.",
+			allowed: []string{"*.Example.com"},
+			url:     "https://Connect.EXAMPLE.com",
+			want:    true,
+		},
+		"Scheme": {
+			reason:  "URLs that are not HTTP should not be allowed.",
+			allowed: []string{"connect.example.com"},
+			url:     "file://connect.example.com/etc/passwd",
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			pc := &v1alpha1.ProviderConfig{Spec: v1alpha1.ProviderConfigSpec{AllowedConnectorURLHosts: tc.allowed}}
+			err := AllowedURL(pc, tc.url)
+			if got := err == nil; got != tc.want {
+				t.Errorf("\n%s\nAllowedURL(...): want allowed %t, got error %v", tc.reason, tc.want, err)
+			}
+		})
+	}
+}
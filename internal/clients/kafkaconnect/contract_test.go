@@ -0,0 +1,143 @@
+package kafkaconnect
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/crossplane/provider-kafkaconnect/internal/clients/kafkaconnect/fake"
+)
+
+// contractFixtures holds a directory of exchanges per Kafka Connect version.
+// Each exchange is stored in a file named after the call below that makes it.
+// Versions without a fixture for a call do not support its endpoint.
+const contractFixtures = "testdata/contract"
+
+const contractConnector = "local-file-source"
+
+// TestContract replays the responses of several Kafka Connect versions to the
+// client, and checks that they are decoded without losing or inventing data
+// by encoding them again.
+func TestContract(t *testing.T) {
+	type call func(ctx context.Context, c *Client) (any, error)
+
+	config := map[string]string{
+		"connector.class": "org.apache.kafka.connect.file.FileStreamSourceConnector",
+		"tasks.max":       "1",
+		"file":            "/tmp/test.txt",
+		"topic":           "connect-test",
+	}
+
+	calls := map[string]call{
+		"root": func(ctx context.Context, c *Client) (any, error) {
+			return c.GetServerInfo(ctx)
+		},
+		"health": func(ctx context.Context, c *Client) (any, error) {
+			return c.GetHealth(ctx)
+		},
+		"create-connector": func(ctx context.Context, c *Client) (any, error) {
+			return c.CreateConnector(ctx, ConnectorConfig{Name: contractConnector, Config: config})
+		},
+		"get-connector": func(ctx context.Context, c *Client) (any, error) {
+			return c.GetConnector(ctx, contractConnector)
+		},
+		"update-connector": func(ctx context.Context, c *Client) (any, error) {
+			return c.UpdateConnector(ctx, contractConnector, config)
+		},
+		"connector-status": func(ctx context.Context, c *Client) (any, error) {
+			return c.GetConnectorStatus(ctx, contractConnector)
+		},
+		"list-connectors-expanded": func(ctx context.Context, c *Client) (any, error) {
+			return c.ListConnectorsExpanded(ctx)
+		},
+		"delete-connector": func(ctx context.Context, c *Client) (any, error) {
+			return nil, c.DeleteConnector(ctx, contractConnector)
+		},
+		"connector-not-found": func(ctx context.Context, c *Client) (any, error) {
+			return c.GetConnector(ctx, "missing")
+		},
+	}
+
+	versions, err := os.ReadDir(contractFixtures)
+	if err != nil {
+		t.Fatalf("cannot read fixtures: %v", err)
+	}
+
+	for _, v := range versions {
+		if !v.IsDir() {
+			continue
+		}
+		dir := filepath.Join(contractFixtures, v.Name())
+		t.Run(v.Name(), func(t *testing.T) {
+			srv, err := fake.NewReplayServer(os.DirFS(dir))
+			if err != nil {
+				t.Fatalf("fake.NewReplayServer(%s): %v", dir, err)
+			}
+			defer srv.Close()
+
+			ctx := context.Background()
+			c := NewClient(srv.URL, WithRetryPolicy(testRetryPolicy))
+
+			for name, fn := range calls {
+				t.Run(name, func(t *testing.T) {
+					b, err := os.ReadFile(filepath.Join(dir, name+".json"))
+					if errors.Is(err, fs.ErrNotExist) {
+						if _, err := fn(ctx, c); !IsUnsupported(err) {
+							t.Errorf("%s: want unsupported error, got %v", name, err)
+						}
+						return
+					}
+					if err != nil {
+						t.Fatalf("cannot read fixture: %v", err)
+					}
+					e := fake.Exchange{}
+					if err := json.Unmarshal(b, &e); err != nil {
+						t.Fatalf("cannot decode fixture: %v", err)
+					}
+
+					got, err := fn(ctx, c)
+					if e.Status >= 400 {
+						var apiErr *APIError
+						if !errors.As(err, &apiErr) {
+							t.Fatalf("%s: want *APIError, got %v", name, err)
+						}
+						got = apiErr
+					} else if err != nil {
+						t.Fatalf("%s: %v", name, err)
+					}
+
+					if len(e.Body) == 0 {
+						return
+					}
+					if diff := cmp.Diff(decode(t, e.Body), roundTrip(t, got)); diff != "" {
+						t.Errorf("%s: -recorded, +round-tripped:\n%s", name, diff)
+					}
+				})
+			}
+		})
+	}
+}
+
+func decode(t *testing.T, b []byte) any {
+	t.Helper()
+	var v any
+	if err := json.Unmarshal(b, &v); err != nil {
+		t.Fatalf("cannot decode JSON: %v", err)
+	}
+	return v
+}
+
+func roundTrip(t *testing.T, v any) any {
+	t.Helper()
+	b, err := json.Marshal(v)
+	if err != nil {
+		t.Fatalf("cannot encode JSON: %v", err)
+	}
+	return decode(t, b)
+}
@@ -0,0 +1,166 @@
+package kafkaconnect
+
+import (
+	"errors"
+	"net/http"
+	"net/url"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+)
+
+// unhealthyFor is how long an endpoint that failed is skipped, unless all
+// endpoints are unhealthy.
+const unhealthyFor = 30 * time.Second
+
+// An EndpointSet is a set of Kafka Connect worker URLs of one cluster. All
+// requests are sent to the active endpoint. The active endpoint is sticky: it
+// changes only when a request to it fails because the worker is unreachable,
+// unavailable or cannot forward the request to the leader.
+type EndpointSet struct {
+	urls []string
+
+	mu             sync.Mutex
+	active         int
+	unhealthyUntil []time.Time
+	now            func() time.Time
+}
+
+// NewEndpointSet returns a set of the supplied worker URLs. The first URL is
+// initially active.
+func NewEndpointSet(urls ...string) *EndpointSet {
+	return &EndpointSet{
+		urls:           urls,
+		unhealthyUntil: make([]time.Time, len(urls)),
+		now:            time.Now,
+	}
+}
+
+// WithEndpoints sends requests to the active endpoint of the supplied set
+// instead of the client's base URL, failing over to other endpoints of the
+// set.
+func WithEndpoints(s *EndpointSet) ClientOption {
+	return func(c *Client) {
+		c.endpoints = s
+	}
+}
+
+// Active returns the URL of the active endpoint.
+func (s *EndpointSet) Active() string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.urls[s.active]
+}
+
+// Report the outcome of a request to the supplied endpoint. If the request
+// failed because of the worker, the endpoint is marked unhealthy and the next
+// healthy endpoint becomes active.
+func (s *EndpointSet) Report(endpoint string, err error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	i := slices.Index(s.urls, endpoint)
+	if i < 0 {
+		return
+	}
+	if err == nil || !isWorkerError(err) {
+		s.unhealthyUntil[i] = time.Time{}
+		return
+	}
+
+	now := s.now()
+	s.unhealthyUntil[i] = now.Add(unhealthyFor)
+	if i != s.active || len(s.urls) == 1 {
+		return
+	}
+
+	// Prefer the next healthy endpoint. If there is none, prefer the one
+	// that becomes healthy first.
+	next := (i + 1) % len(s.urls)
+	for j := 1; j < len(s.urls); j++ {
+		k := (i + j) % len(s.urls)
+		if !s.unhealthyUntil[k].After(now) {
+			next = k
+			break
+		}
+		if s.unhealthyUntil[k].Before(s.unhealthyUntil[next]) {
+			next = k
+		}
+	}
+	s.active = next
+}
+
+// isWorkerError returns true if the supplied error indicates that the worker
+// that handled a request cannot currently serve requests, so that another
+// worker should be tried.
+func isWorkerError(err error) bool {
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) {
+		// No response, e.g. because the connection was refused or timed out.
+		return true
+	}
+	switch apiErr.StatusCode {
+	case http.StatusConflict:
+		return !IsAlreadyExists(apiErr)
+	case http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+		return true
+	}
+	return isForwardingError(apiErr)
+}
+
+// isForwardingError returns true if a follower failed to forward a request
+// to the leader.
+func isForwardingError(err *APIError) bool {
+	return err.StatusCode == http.StatusInternalServerError && strings.Contains(strings.ToLower(err.Message), "forward")
+}
+
+// retarget returns the supplied request with its URL replaced by the
+// supplied one, keeping the query.
+func retarget(req *http.Request, rawURL string) (*http.Request, error) {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return nil, err
+	}
+	u.RawQuery = req.URL.RawQuery
+	if u.String() == req.URL.String() {
+		return req, nil
+	}
+	r := req.WithContext(req.Context())
+	r.URL = u
+	r.Host = ""
+	return r, nil
+}
+
+var endpointSets = struct {
+	sync.Mutex
+	sets map[string]*EndpointSet
+}{sets: map[string]*EndpointSet{}}
+
+// EndpointsFor returns the endpoint set of the named ProviderConfig. Sets are
+// shared by all clients of a ProviderConfig, so that they agree on the active
+// endpoint. The set is replaced if the supplied URLs differ from the ones it
+// was created with.
+func EndpointsFor(providerConfig string, urls []string) *EndpointSet {
+	endpointSets.Lock()
+	defer endpointSets.Unlock()
+
+	if s, ok := endpointSets.sets[providerConfig]; ok && slices.Equal(s.urls, urls) {
+		return s
+	}
+	s := NewEndpointSet(urls...)
+	endpointSets.sets[providerConfig] = s
+	return s
+}
+
+// ActiveEndpoint returns the active endpoint of the named ProviderConfig, or
+// an empty string if no client was created for it yet.
+func ActiveEndpoint(providerConfig string) string {
+	endpointSets.Lock()
+	s, ok := endpointSets.sets[providerConfig]
+	endpointSets.Unlock()
+	if !ok {
+		return ""
+	}
+	return s.Active()
+}
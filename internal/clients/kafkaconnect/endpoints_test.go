@@ -0,0 +1,88 @@
+package kafkaconnect
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestEndpointSetReport(t *testing.T) {
+	errUnreachable := errors.New("connection refused")
+	errBadRequest := &APIError{StatusCode: http.StatusBadRequest}
+	errForwarding := &APIError{StatusCode: http.StatusInternalServerError, Message: "IO Error trying to forward REST request"}
+
+	type report struct {
+		endpoint string
+		err      error
+	}
+
+	cases := map[string]struct {
+		reason  string
+		reports []report
+		want    string
+	}{
+		"Sticky": {
+			reason:  "The active endpoint should not change while requests succeed.",
+			reports: []report{{"a", nil}, {"a", errBadRequest}},
+			want:    "a",
+		},
+		"FailOver": {
+			reason:  "The next endpoint should become active if the active one is unreachable.",
+			reports: []report{{"a", errUnreachable}},
+			want:    "b",
+		},
+		"ForwardingFailed": {
+			reason:  "The next endpoint should become active if the active one cannot forward requests.",
+			reports: []report{{"a", errForwarding}},
+			want:    "b",
+		},
+		"SkipUnhealthy": {
+			reason:  "Endpoints that failed recently should be skipped.",
+			reports: []report{{"b", errUnreachable}, {"a", errUnreachable}},
+			want:    "c",
+		},
+		"AllUnhealthy": {
+			reason:  "The endpoint that becomes healthy first should be preferred if all are unhealthy.",
+			reports: []report{{"b", errUnreachable}, {"c", errUnreachable}, {"a", errUnreachable}},
+			want:    "b",
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			s := NewEndpointSet("a", "b", "c")
+			now := time.Now()
+			s.now = func() time.Time { now = now.Add(time.Second); return now }
+			for _, r := range tc.reports {
+				s.Report(r.endpoint, r.err)
+			}
+			if diff := cmp.Diff(tc.want, s.Active()); diff != "" {
+				t.Errorf("\n%s\ns.Active(): -want, +got:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestClientFailover(t *testing.T) {
+	down := httptest.NewServer(http.NotFoundHandler())
+	down.Close()
+
+	up := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		_, _ = w.Write([]byte(`{"name":"a","config":{},"tasks":[]}`))
+	}))
+	defer up.Close()
+
+	s := NewEndpointSet(down.URL, up.URL)
+	c := NewClient(down.URL, WithEndpoints(s), WithRetryPolicy(testRetryPolicy))
+	if _, err := c.GetConnector(context.Background(), "a"); err != nil {
+		t.Fatalf("c.GetConnector(...): %v", err)
+	}
+	if diff := cmp.Diff(up.URL, s.Active()); diff != "" {
+		t.Errorf("s.Active(): -want, +got:\n%s", diff)
+	}
+}
@@ -0,0 +1,477 @@
+package fake
+
+import (
+	"encoding/json"
+	"fmt"
+	"maps"
+	"net/http"
+	"slices"
+	"strconv"
+	"time"
+)
+
+// Wire types of the Kafka Connect REST API.
+type (
+	serverInfo struct {
+		Version        string `json:"version"`
+		Commit         string `json:"commit"`
+		KafkaClusterID string `json:"kafka_cluster_id"`
+		GroupID        string `json:"group_id,omitempty"`
+	}
+
+	apiError struct {
+		ErrorCode int    `json:"error_code"`
+		Message   string `json:"message"`
+	}
+
+	taskID struct {
+		Connector string `json:"connector"`
+		Task      int    `json:"task"`
+	}
+
+	connectorInfo struct {
+		Name   string            `json:"name"`
+		Config map[string]string `json:"config"`
+		Tasks  []taskID          `json:"tasks"`
+		Type   string            `json:"type"`
+	}
+
+	state struct {
+		ID       *int   `json:"id,omitempty"`
+		State    string `json:"state"`
+		WorkerID string `json:"worker_id"`
+		Trace    string `json:"trace,omitempty"`
+	}
+
+	connectorStatus struct {
+		Name      string  `json:"name"`
+		Connector state   `json:"connector"`
+		Tasks     []state `json:"tasks"`
+		Type      string  `json:"type"`
+	}
+
+	taskInfo struct {
+		ID     taskID            `json:"id"`
+		Config map[string]string `json:"config"`
+	}
+
+	createRequest struct {
+		Name         string            `json:"name"`
+		Config       map[string]string `json:"config"`
+		InitialState string            `json:"initial_state,omitempty"`
+	}
+
+	offsets struct {
+		Offsets []Offset `json:"offsets"`
+	}
+
+	configValue struct {
+		Name   string   `json:"name"`
+		Value  *string  `json:"value"`
+		Errors []string `json:"errors"`
+	}
+
+	configInfo struct {
+		Definition struct {
+			Name string `json:"name"`
+		} `json:"definition"`
+		Value configValue `json:"value"`
+	}
+
+	validation struct {
+		Name       string       `json:"name"`
+		ErrorCount int          `json:"error_count"`
+		Groups     []string     `json:"groups"`
+		Configs    []configInfo `json:"configs"`
+	}
+)
+
+func (s *Server) handler() http.Handler {
+	mux := http.NewServeMux()
+
+	mux.HandleFunc("GET /{$}", s.getRoot)
+	mux.HandleFunc("GET /health", s.since(3, 9, s.getHealth))
+	mux.HandleFunc("GET /connectors", s.listConnectors)
+	mux.HandleFunc("POST /connectors", s.createConnector)
+	mux.HandleFunc("GET /connectors/{name}", s.withConnector(s.getConnector))
+	mux.HandleFunc("DELETE /connectors/{name}", s.withConnector(s.deleteConnector))
+	mux.HandleFunc("GET /connectors/{name}/config", s.withConnector(s.getConfig))
+	mux.HandleFunc("PUT /connectors/{name}/config", s.putConfig)
+	mux.HandleFunc("PATCH /connectors/{name}/config", s.since(3, 8, s.withConnector(s.patchConfig)))
+	mux.HandleFunc("GET /connectors/{name}/status", s.withConnector(s.getStatus))
+	mux.HandleFunc("GET /connectors/{name}/tasks", s.withConnector(s.getTasks))
+	mux.HandleFunc("PUT /connectors/{name}/pause", s.withConnector(s.transition(StatePaused)))
+	mux.HandleFunc("PUT /connectors/{name}/resume", s.withConnector(s.transition(StateRunning)))
+	mux.HandleFunc("PUT /connectors/{name}/stop", s.since(3, 5, s.withConnector(s.transition(StateStopped))))
+	mux.HandleFunc("POST /connectors/{name}/restart", s.withConnector(s.restartConnector))
+	mux.HandleFunc("POST /connectors/{name}/tasks/{task}/restart", s.withConnector(s.restartTask))
+	mux.HandleFunc("GET /connectors/{name}/offsets", s.since(3, 5, s.withConnector(s.getOffsets)))
+	mux.HandleFunc("PATCH /connectors/{name}/offsets", s.since(3, 6, s.withConnector(s.alterOffsets)))
+	mux.HandleFunc("DELETE /connectors/{name}/offsets", s.since(3, 6, s.withConnector(s.resetOffsets)))
+	mux.HandleFunc("GET /connector-plugins", s.listPlugins)
+	mux.HandleFunc("PUT /connector-plugins/{class}/config/validate", s.validate)
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		s.mu.Lock()
+		s.requests = append(s.requests, Request{Method: r.Method, Path: r.URL.Path})
+		f := s.fault(r)
+		authorized := s.authorized(r)
+		s.mu.Unlock()
+
+		if !authorized {
+			writeError(w, http.StatusUnauthorized, "Unauthorized")
+			return
+		}
+
+		if f != nil {
+			select {
+			case <-time.After(f.Latency):
+			case <-r.Context().Done():
+				return
+			}
+			if f.Status != 0 {
+				writeError(w, f.Status, f.Message)
+				return
+			}
+		}
+
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		mux.ServeHTTP(w, r)
+	})
+}
+
+// authorized returns true if the supplied request carries the credentials
+// the server requires, if any.
+func (s *Server) authorized(r *http.Request) bool {
+	if s.username == "" {
+		return true
+	}
+	u, p, ok := r.BasicAuth()
+	return ok && u == s.username && p == s.password
+}
+
+// fault returns the first fault matching the supplied request, if any.
+func (s *Server) fault(r *http.Request) *Fault {
+	for i, f := range s.faults {
+		if !f.matches(r) {
+			continue
+		}
+		if f.Times > 0 {
+			f.Times--
+			if f.Times == 0 {
+				s.faults = slices.Delete(s.faults, i, i+1)
+			}
+		}
+		return f
+	}
+	return nil
+}
+
+// since answers 404 to requests for endpoints that were introduced after the
+// server's version.
+func (s *Server) since(major, minor int, h http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if !s.supports(major, minor) {
+			writeError(w, http.StatusNotFound, "HTTP 404 Not Found")
+			return
+		}
+		h(w, r)
+	}
+}
+
+// withConnector answers 404 to requests for connectors that do not exist.
+func (s *Server) withConnector(h func(w http.ResponseWriter, r *http.Request, c *Connector)) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		name := r.PathValue("name")
+		c, ok := s.connectors[name]
+		if !ok {
+			writeError(w, http.StatusNotFound, fmt.Sprintf("Connector %s not found", name))
+			return
+		}
+		h(w, r, c)
+	}
+}
+
+func (s *Server) getRoot(w http.ResponseWriter, _ *http.Request) {
+	writeJSON(w, http.StatusOK, serverInfo{Version: s.version, Commit: "fake", KafkaClusterID: s.kafkaClusterID, GroupID: s.groupID})
+}
+
+func (s *Server) getHealth(w http.ResponseWriter, _ *http.Request) {
+	writeJSON(w, http.StatusOK, map[string]string{"status": "healthy", "message": "Worker has completed startup and is ready to handle requests."})
+}
+
+func (s *Server) listConnectors(w http.ResponseWriter, r *http.Request) {
+	names := slices.Sorted(maps.Keys(s.connectors))
+	expand := r.URL.Query()["expand"]
+	if len(expand) == 0 {
+		writeJSON(w, http.StatusOK, names)
+		return
+	}
+
+	out := map[string]map[string]any{}
+	for _, name := range names {
+		e := map[string]any{}
+		if slices.Contains(expand, "info") {
+			e["info"] = info(s.connectors[name])
+		}
+		if slices.Contains(expand, "status") {
+			e["status"] = status(s.connectors[name])
+		}
+		out[name] = e
+	}
+	writeJSON(w, http.StatusOK, out)
+}
+
+func (s *Server) createConnector(w http.ResponseWriter, r *http.Request) {
+	req := createRequest{}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	if req.Name == "" {
+		writeError(w, http.StatusBadRequest, "Connector name cannot be empty")
+		return
+	}
+	if _, ok := s.connectors[req.Name]; ok {
+		writeError(w, http.StatusConflict, fmt.Sprintf("Connector %s already exists", req.Name))
+		return
+	}
+	if req.InitialState != "" && !s.supports(3, 7) {
+		writeError(w, http.StatusBadRequest, "Unrecognized field \"initial_state\"")
+		return
+	}
+	if msg, ok := s.invalid(req.Config); !ok {
+		writeError(w, http.StatusBadRequest, msg)
+		return
+	}
+
+	c := s.newConnector(req.Name, req.Config, req.InitialState)
+	s.connectors[c.Name] = c
+	writeJSON(w, http.StatusCreated, info(c))
+}
+
+func (s *Server) getConnector(w http.ResponseWriter, _ *http.Request, c *Connector) {
+	writeJSON(w, http.StatusOK, info(c))
+}
+
+func (s *Server) deleteConnector(w http.ResponseWriter, _ *http.Request, c *Connector) {
+	delete(s.connectors, c.Name)
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (s *Server) getConfig(w http.ResponseWriter, _ *http.Request, c *Connector) {
+	writeJSON(w, http.StatusOK, c.Config)
+}
+
+func (s *Server) putConfig(w http.ResponseWriter, r *http.Request) {
+	config := map[string]string{}
+	if err := json.NewDecoder(r.Body).Decode(&config); err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	if msg, ok := s.invalid(config); !ok {
+		writeError(w, http.StatusBadRequest, msg)
+		return
+	}
+
+	name := r.PathValue("name")
+	c, ok := s.connectors[name]
+	if !ok {
+		c = s.newConnector(name, config, "")
+		s.connectors[name] = c
+		writeJSON(w, http.StatusCreated, info(c))
+		return
+	}
+	s.configure(c, config)
+	writeJSON(w, http.StatusOK, info(c))
+}
+
+func (s *Server) patchConfig(w http.ResponseWriter, r *http.Request, c *Connector) {
+	patch := map[string]*string{}
+	if err := json.NewDecoder(r.Body).Decode(&patch); err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	config := maps.Clone(c.Config)
+	for k, v := range patch {
+		if v == nil {
+			delete(config, k)
+			continue
+		}
+		config[k] = *v
+	}
+	if msg, ok := s.invalid(config); !ok {
+		writeError(w, http.StatusBadRequest, msg)
+		return
+	}
+	s.configure(c, config)
+	writeJSON(w, http.StatusOK, info(c))
+}
+
+func (s *Server) getStatus(w http.ResponseWriter, _ *http.Request, c *Connector) {
+	writeJSON(w, http.StatusOK, status(c))
+}
+
+func (s *Server) getTasks(w http.ResponseWriter, _ *http.Request, c *Connector) {
+	tasks := make([]taskInfo, 0, len(c.Tasks))
+	for _, t := range c.Tasks {
+		tasks = append(tasks, taskInfo{ID: taskID{Connector: c.Name, Task: t.ID}, Config: maps.Clone(c.Config)})
+	}
+	writeJSON(w, http.StatusOK, tasks)
+}
+
+func (s *Server) transition(to string) func(w http.ResponseWriter, r *http.Request, c *Connector) {
+	return func(w http.ResponseWriter, _ *http.Request, c *Connector) {
+		s.setState(c, to)
+		w.WriteHeader(http.StatusAccepted)
+	}
+}
+
+func (s *Server) restartConnector(w http.ResponseWriter, r *http.Request, c *Connector) {
+	q := r.URL.Query()
+	includeTasks := q.Get("includeTasks") == "true"
+	onlyFailed := q.Get("onlyFailed") == "true"
+
+	if !onlyFailed || c.State == StateFailed {
+		c.Restarts++
+		c.State, c.Trace = StateRunning, ""
+	}
+	if includeTasks {
+		for i := range c.Tasks {
+			if !onlyFailed || c.Tasks[i].State == StateFailed {
+				c.Tasks[i].Restarts++
+				c.Tasks[i].State, c.Tasks[i].Trace = StateRunning, ""
+			}
+		}
+	}
+
+	// Workers answer with the status if tasks may be restarted too.
+	if includeTasks || onlyFailed {
+		writeJSON(w, http.StatusAccepted, status(c))
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (s *Server) restartTask(w http.ResponseWriter, r *http.Request, c *Connector) {
+	id, err := strconv.Atoi(r.PathValue("task"))
+	if err != nil || id < 0 || id >= len(c.Tasks) {
+		writeError(w, http.StatusNotFound, fmt.Sprintf("Task %s-%s not found", c.Name, r.PathValue("task")))
+		return
+	}
+	c.Tasks[id].Restarts++
+	c.Tasks[id].State, c.Tasks[id].Trace = StateRunning, ""
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (s *Server) getOffsets(w http.ResponseWriter, _ *http.Request, c *Connector) {
+	writeJSON(w, http.StatusOK, offsets{Offsets: append([]Offset{}, c.Offsets...)})
+}
+
+func (s *Server) alterOffsets(w http.ResponseWriter, r *http.Request, c *Connector) {
+	if c.State != StateStopped {
+		writeError(w, http.StatusBadRequest, "Connectors must be in the STOPPED state before their offsets can be modified. This can be done for the specified connector by issuing a 'PUT' request to the '/connectors/"+c.Name+"/stop' endpoint")
+		return
+	}
+	req := offsets{}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	c.Offsets = req.Offsets
+	writeJSON(w, http.StatusOK, map[string]string{"message": "The offsets for this connector have been altered successfully"})
+}
+
+func (s *Server) resetOffsets(w http.ResponseWriter, _ *http.Request, c *Connector) {
+	if c.State != StateStopped {
+		writeError(w, http.StatusBadRequest, "Connectors must be in the STOPPED state before their offsets can be modified. This can be done for the specified connector by issuing a 'PUT' request to the '/connectors/"+c.Name+"/stop' endpoint")
+		return
+	}
+	c.Offsets = nil
+	writeJSON(w, http.StatusOK, map[string]string{"message": "The offsets for this connector have been reset successfully"})
+}
+
+func (s *Server) listPlugins(w http.ResponseWriter, _ *http.Request) {
+	writeJSON(w, http.StatusOK, append([]Plugin{}, s.plugins...))
+}
+
+func (s *Server) validate(w http.ResponseWriter, r *http.Request) {
+	config := map[string]string{}
+	if err := json.NewDecoder(r.Body).Decode(&config); err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	class := r.PathValue("class")
+	if !s.knownPlugin(class) {
+		writeError(w, http.StatusBadRequest, "Failed to find any class that implements Connector and which name matches "+class)
+		return
+	}
+	writeJSON(w, http.StatusOK, s.validation(class, config))
+}
+
+// validation returns the validation result of the supplied config.
+func (s *Server) validation(class string, config map[string]string) validation {
+	v := validation{Name: class, Groups: []string{"Common"}}
+	for _, k := range slices.Sorted(maps.Keys(config)) {
+		ci := configInfo{Value: configValue{Name: k, Value: ptr(config[k]), Errors: []string{}}}
+		ci.Definition.Name = k
+		if msg, ok := s.validationErrors[k]; ok {
+			ci.Value.Errors = append(ci.Value.Errors, msg)
+			v.ErrorCount++
+		}
+		v.Configs = append(v.Configs, ci)
+	}
+	if _, ok := config["connector.class"]; !ok {
+		ci := configInfo{Value: configValue{Name: "connector.class", Errors: []string{"Missing required configuration \"connector.class\" which has no default value."}}}
+		ci.Definition.Name = "connector.class"
+		v.Configs = append(v.Configs, ci)
+		v.ErrorCount++
+	}
+	return v
+}
+
+// invalid returns a message and false if the supplied config is invalid.
+func (s *Server) invalid(config map[string]string) (string, bool) {
+	class := config["connector.class"]
+	if class == "" || !s.knownPlugin(class) {
+		return "Connector config " + fmt.Sprint(config) + " contains no connector type", false
+	}
+	if v := s.validation(class, config); v.ErrorCount > 0 {
+		return fmt.Sprintf("Connector configuration is invalid and contains the following %d error(s)", v.ErrorCount), false
+	}
+	return "", true
+}
+
+func info(c *Connector) connectorInfo {
+	ci := connectorInfo{Name: c.Name, Config: maps.Clone(c.Config), Tasks: []taskID{}, Type: c.Type}
+	for _, t := range c.Tasks {
+		ci.Tasks = append(ci.Tasks, taskID{Connector: c.Name, Task: t.ID})
+	}
+	return ci
+}
+
+func status(c *Connector) connectorStatus {
+	cs := connectorStatus{
+		Name:      c.Name,
+		Connector: state{State: c.State, WorkerID: c.WorkerID, Trace: c.Trace},
+		Tasks:     []state{},
+		Type:      c.Type,
+	}
+	for _, t := range c.Tasks {
+		cs.Tasks = append(cs.Tasks, state{ID: ptr(t.ID), State: t.State, WorkerID: t.WorkerID, Trace: t.Trace})
+	}
+	return cs
+}
+
+func writeJSON(w http.ResponseWriter, code int, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(code)
+	_ = json.NewEncoder(w).Encode(v)
+}
+
+func writeError(w http.ResponseWriter, code int, message string) {
+	writeJSON(w, code, apiError{ErrorCode: code, Message: message})
+}
+
+func ptr[T any](v T) *T { return &v }
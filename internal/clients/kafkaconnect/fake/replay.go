@@ -0,0 +1,97 @@
+package fake
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/fs"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"path"
+	"slices"
+	"strings"
+)
+
+// An Exchange is a request and the response Kafka Connect answered it with.
+type Exchange struct {
+	// Method of the request.
+	Method string `json:"method"`
+
+	// Path of the request, including the query if any.
+	Path string `json:"path"`
+
+	// Status of the response.
+	Status int `json:"status"`
+
+	// Body of the response, if any.
+	Body json.RawMessage `json:"body,omitempty"`
+}
+
+// A ReplayServer answers requests with recorded exchanges. Requests without an
+// exchange are answered with 404 Not Found.
+type ReplayServer struct {
+	*httptest.Server
+
+	exchanges map[string]Exchange
+}
+
+// NewReplayServer starts a server replaying the exchanges in the JSON files at
+// the root of the supplied file system. It must be closed once the test is
+// done.
+func NewReplayServer(fsys fs.FS) (*ReplayServer, error) {
+	files, err := fs.Glob(fsys, "*.json")
+	if err != nil {
+		return nil, err
+	}
+	s := &ReplayServer{exchanges: map[string]Exchange{}}
+	for _, f := range files {
+		b, err := fs.ReadFile(fsys, f)
+		if err != nil {
+			return nil, err
+		}
+		e := Exchange{}
+		if err := json.Unmarshal(b, &e); err != nil {
+			return nil, fmt.Errorf("cannot decode exchange %s: %w", f, err)
+		}
+		u, err := url.Parse(e.Path)
+		if err != nil {
+			return nil, fmt.Errorf("cannot parse path of exchange %s: %w", f, err)
+		}
+		k := exchangeKey(e.Method, u)
+		if _, ok := s.exchanges[k]; ok {
+			return nil, fmt.Errorf("exchange %s duplicates %s", f, k)
+		}
+		s.exchanges[k] = e
+	}
+	s.Server = httptest.NewServer(http.HandlerFunc(s.serve))
+	return s, nil
+}
+
+func (s *ReplayServer) serve(w http.ResponseWriter, r *http.Request) {
+	e, ok := s.exchanges[exchangeKey(r.Method, r.URL)]
+	if !ok {
+		writeError(w, http.StatusNotFound, fmt.Sprintf("no exchange recorded for %s", exchangeKey(r.Method, r.URL)))
+		return
+	}
+	if len(e.Body) == 0 {
+		w.WriteHeader(e.Status)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(e.Status)
+	_, _ = w.Write(e.Body)
+}
+
+// exchangeKey identifies an exchange by the method, path and query of its
+// request. Query values are sorted, so their order does not matter.
+func exchangeKey(method string, u *url.URL) string {
+	q := u.Query()
+	for k := range q {
+		slices.Sort(q[k])
+	}
+	k := strings.ToUpper(method) + " " + path.Clean("/"+u.Path)
+	if len(q) > 0 {
+		k += "?" + q.Encode()
+	}
+	return k
+}
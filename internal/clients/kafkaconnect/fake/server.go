@@ -0,0 +1,346 @@
+// Package fake provides an in-memory Kafka Connect REST API for tests.
+package fake
+
+import (
+	"maps"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Connector states.
+const (
+	StateRunning    = "RUNNING"
+	StatePaused     = "PAUSED"
+	StateStopped    = "STOPPED"
+	StateFailed     = "FAILED"
+	StateUnassigned = "UNASSIGNED"
+)
+
+// MessageRebalance is the message Kafka Connect answers with while a
+// rebalance is in progress.
+const MessageRebalance = "Cannot complete request momentarily due to stale configuration (typically caused by a concurrent config change)"
+
+// A Connector modelled by the server.
+type Connector struct {
+	Name     string
+	Type     string
+	Config   map[string]string
+	State    string
+	WorkerID string
+	Trace    string
+	Tasks    []Task
+
+	// Offsets are the offsets of the connector, in the format of the offsets
+	// endpoints.
+	Offsets []Offset
+
+	// Restarts counts how often the connector itself was restarted.
+	Restarts int
+}
+
+// A Task of a connector.
+type Task struct {
+	ID       int
+	State    string
+	WorkerID string
+	Trace    string
+	Restarts int
+}
+
+// An Offset of a connector.
+type Offset struct {
+	Partition map[string]any `json:"partition"`
+	Offset    map[string]any `json:"offset"`
+}
+
+// A Plugin installed on the workers.
+type Plugin struct {
+	Class   string `json:"class"`
+	Type    string `json:"type"`
+	Version string `json:"version,omitempty"`
+}
+
+// A Fault is injected into the responses of the server.
+type Fault struct {
+	// Method of the requests to fail. All methods if empty.
+	Method string
+
+	// Path prefix of the requests to fail. All paths if empty.
+	Path string
+
+	// Status answered instead of the real response. The request is served
+	// normally after the latency if zero.
+	Status int
+
+	// Message of the error response.
+	Message string
+
+	// Latency added before the request is answered.
+	Latency time.Duration
+
+	// Times the fault is injected. Always if zero.
+	Times int
+}
+
+func (f *Fault) matches(r *http.Request) bool {
+	return (f.Method == "" || f.Method == r.Method) && strings.HasPrefix(r.URL.Path, f.Path)
+}
+
+// A Request received by the server.
+type Request struct {
+	Method string
+	Path   string
+}
+
+// An Option configures a server.
+type Option func(s *Server)
+
+// WithVersion sets the Kafka Connect version the server reports. Endpoints
+// introduced after it answer 404 or 405, like real workers do.
+func WithVersion(v string) Option {
+	return func(s *Server) {
+		s.version = v
+	}
+}
+
+// WithKafkaClusterID sets the Kafka cluster ID the server reports.
+func WithKafkaClusterID(id string) Option {
+	return func(s *Server) {
+		s.kafkaClusterID = id
+	}
+}
+
+// WithGroupID sets the Connect group ID the server reports. Apache Kafka
+// workers do not report it.
+func WithGroupID(id string) Option {
+	return func(s *Server) {
+		s.groupID = id
+	}
+}
+
+// WithPlugins sets the plugins installed on the workers. Connectors of other
+// classes are rejected if any plugins are set.
+func WithPlugins(p ...Plugin) Option {
+	return func(s *Server) {
+		s.plugins = p
+	}
+}
+
+// WithConnectors adds existing connectors.
+func WithConnectors(cs ...Connector) Option {
+	return func(s *Server) {
+		for _, c := range cs {
+			s.connectors[c.Name] = c.deepCopy()
+		}
+	}
+}
+
+// WithValidationError makes validation of the supplied config key fail with
+// the supplied message.
+func WithValidationError(key, message string) Option {
+	return func(s *Server) {
+		s.validationErrors[key] = message
+	}
+}
+
+// WithBasicAuth makes the server answer 401 Unauthorized to requests without
+// the supplied basic auth credentials.
+func WithBasicAuth(username, password string) Option {
+	return func(s *Server) {
+		s.username, s.password = username, password
+	}
+}
+
+// A Server is an in-memory Kafka Connect cluster serving the REST API.
+type Server struct {
+	*httptest.Server
+
+	mu               sync.Mutex
+	version          string
+	kafkaClusterID   string
+	groupID          string
+	workerID         string
+	username         string
+	password         string
+	plugins          []Plugin
+	connectors       map[string]*Connector
+	validationErrors map[string]string
+	faults           []*Fault
+	requests         []Request
+}
+
+// NewServer starts a server. It must be closed once the test is done.
+func NewServer(o ...Option) *Server {
+	s := &Server{
+		version:          "3.9.0",
+		kafkaClusterID:   "fake-kafka-cluster",
+		workerID:         "fake-worker:8083",
+		connectors:       map[string]*Connector{},
+		validationErrors: map[string]string{},
+	}
+	for _, fn := range o {
+		fn(s)
+	}
+	s.Server = httptest.NewServer(s.handler())
+	return s
+}
+
+// InjectFault makes the server answer matching requests with the fault.
+func (s *Server) InjectFault(f Fault) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.faults = append(s.faults, &f)
+}
+
+// SetBasicAuth changes the basic auth credentials the server requires, e.g. to
+// simulate a credential rotation. Credentials are not required if the
+// username is empty.
+func (s *Server) SetBasicAuth(username, password string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.username, s.password = username, password
+}
+
+// ClearFaults removes all injected faults.
+func (s *Server) ClearFaults() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.faults = nil
+}
+
+// Rebalance makes the server answer the next n requests with 409 Conflict,
+// like workers do while a rebalance is in progress.
+func (s *Server) Rebalance(n int) {
+	s.InjectFault(Fault{Status: http.StatusConflict, Message: MessageRebalance, Times: n})
+}
+
+// SetConnectorState sets the state and trace of the named connector, e.g. to
+// simulate a failure.
+func (s *Server) SetConnectorState(name, state, trace string) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	c, ok := s.connectors[name]
+	if ok {
+		c.State, c.Trace = state, trace
+	}
+	return ok
+}
+
+// SetTaskState sets the state and trace of a task of the named connector.
+func (s *Server) SetTaskState(name string, id int, state, trace string) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	c, ok := s.connectors[name]
+	if !ok || id < 0 || id >= len(c.Tasks) {
+		return false
+	}
+	c.Tasks[id].State, c.Tasks[id].Trace = state, trace
+	return true
+}
+
+// Connector returns a copy of the named connector.
+func (s *Server) Connector(name string) (Connector, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	c, ok := s.connectors[name]
+	if !ok {
+		return Connector{}, false
+	}
+	return *c.deepCopy(), true
+}
+
+// Connectors returns the names of all connectors, sorted.
+func (s *Server) Connectors() []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return slices.Sorted(maps.Keys(s.connectors))
+}
+
+// Requests returns the requests received so far.
+func (s *Server) Requests() []Request {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return slices.Clone(s.requests)
+}
+
+func (c Connector) deepCopy() *Connector {
+	out := c
+	out.Config = maps.Clone(c.Config)
+	out.Tasks = slices.Clone(c.Tasks)
+	out.Offsets = slices.Clone(c.Offsets)
+	return &out
+}
+
+// newConnector returns a running connector with tasks.max running tasks.
+func (s *Server) newConnector(name string, config map[string]string, state string) *Connector {
+	c := &Connector{Name: name, Type: s.pluginType(config["connector.class"]), WorkerID: s.workerID}
+	s.configure(c, config)
+	if state != "" {
+		s.setState(c, state)
+	}
+	return c
+}
+
+// configure the supplied connector, restarting its tasks.
+func (s *Server) configure(c *Connector, config map[string]string) {
+	c.Config = maps.Clone(config)
+	c.Config["name"] = c.Name
+	if c.State == "" || c.State == StateFailed {
+		c.State = StateRunning
+	}
+	c.Trace = ""
+	s.setState(c, c.State)
+}
+
+// setState sets the state of a connector and its tasks. Stopped connectors
+// have no tasks.
+func (s *Server) setState(c *Connector, state string) {
+	c.State = state
+	c.Tasks = nil
+	if state == StateStopped {
+		return
+	}
+	n, err := strconv.Atoi(c.Config["tasks.max"])
+	if err != nil || n < 1 {
+		n = 1
+	}
+	for i := range n {
+		c.Tasks = append(c.Tasks, Task{ID: i, State: state, WorkerID: s.workerID})
+	}
+}
+
+func (s *Server) pluginType(class string) string {
+	for _, p := range s.plugins {
+		if p.Class == class {
+			return p.Type
+		}
+	}
+	return "source"
+}
+
+func (s *Server) knownPlugin(class string) bool {
+	if len(s.plugins) == 0 {
+		return true
+	}
+	return slices.ContainsFunc(s.plugins, func(p Plugin) bool { return p.Class == class })
+}
+
+// supports returns true if the server's version is at least the supplied
+// major and minor version.
+func (s *Server) supports(major, minor int) bool {
+	parts := strings.SplitN(strings.SplitN(s.version, "-", 2)[0], ".", 3)
+	if len(parts) < 2 {
+		return true
+	}
+	vmajor, err1 := strconv.Atoi(parts[0])
+	vminor, err2 := strconv.Atoi(parts[1])
+	if err1 != nil || err2 != nil {
+		return true
+	}
+	return vmajor > major || vmajor == major && vminor >= minor
+}
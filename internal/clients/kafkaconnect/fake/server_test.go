@@ -0,0 +1,91 @@
+package fake
+
+import (
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestServer(t *testing.T) {
+	type request struct {
+		method string
+		path   string
+		body   string
+	}
+
+	cases := map[string]struct {
+		reason   string
+		opts     []Option
+		faults   []Fault
+		requests []request
+		want     []int
+	}{
+		"Lifecycle": {
+			reason: "Connectors should be created, stopped, have their offsets reset and be deleted.",
+			requests: []request{
+				{http.MethodPost, "/connectors", `{"name":"a","config":{"connector.class":"c"}}`},
+				{http.MethodPost, "/connectors", `{"name":"a","config":{"connector.class":"c"}}`},
+				{http.MethodDelete, "/connectors/a/offsets", ""},
+				{http.MethodPut, "/connectors/a/stop", ""},
+				{http.MethodDelete, "/connectors/a/offsets", ""},
+				{http.MethodDelete, "/connectors/a", ""},
+				{http.MethodGet, "/connectors/a", ""},
+			},
+			want: []int{http.StatusCreated, http.StatusConflict, http.StatusBadRequest, http.StatusAccepted, http.StatusOK, http.StatusNoContent, http.StatusNotFound},
+		},
+		"OldVersion": {
+			reason: "Endpoints introduced after the server's version should not exist.",
+			opts:   []Option{WithVersion("3.4.1")},
+			requests: []request{
+				{http.MethodGet, "/health", ""},
+				{http.MethodGet, "/", ""},
+			},
+			want: []int{http.StatusNotFound, http.StatusOK},
+		},
+		"BasicAuth": {
+			reason: "Requests without the required credentials should be rejected.",
+			opts:   []Option{WithBasicAuth("admin", "secret")},
+			requests: []request{
+				{http.MethodGet, "/", ""},
+			},
+			want: []int{http.StatusUnauthorized},
+		},
+		"Faults": {
+			reason: "Faults should be injected the configured number of times.",
+			faults: []Fault{{Path: "/connectors", Status: http.StatusServiceUnavailable, Times: 2}},
+			requests: []request{
+				{http.MethodGet, "/connectors", ""},
+				{http.MethodGet, "/", ""},
+				{http.MethodGet, "/connectors", ""},
+				{http.MethodGet, "/connectors", ""},
+			},
+			want: []int{http.StatusServiceUnavailable, http.StatusOK, http.StatusServiceUnavailable, http.StatusOK},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			s := NewServer(tc.opts...)
+			defer s.Close()
+			for _, f := range tc.faults {
+				s.InjectFault(f)
+			}
+
+			got := make([]int, 0, len(tc.requests))
+			for _, r := range tc.requests {
+				req, _ := http.NewRequest(r.method, s.URL+r.path, strings.NewReader(r.body))
+				resp, err := http.DefaultClient.Do(req)
+				if err != nil {
+					t.Fatalf("\n%s\n%s %s: %v", tc.reason, r.method, r.path, err)
+				}
+				_ = resp.Body.Close()
+				got = append(got, resp.StatusCode)
+			}
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("\n%s\nstatus codes: -want, +got:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,72 @@
+package kafkaconnect
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/crossplane/provider-kafkaconnect/internal/clients/kafkaconnect/fake"
+)
+
+func TestClientLifecycle(t *testing.T) {
+	srv := fake.NewServer(fake.WithPlugins(fake.Plugin{Class: "FileStreamSource", Type: "source"}))
+	defer srv.Close()
+
+	// A rebalance in progress should be retried transparently.
+	srv.Rebalance(1)
+
+	ctx := context.Background()
+	c := NewClient(srv.URL, WithRetryPolicy(testRetryPolicy))
+
+	config := map[string]string{"connector.class": "FileStreamSource", "tasks.max": "2"}
+	if _, err := c.CreateConnector(ctx, ConnectorConfig{Name: "a", Config: config}); err != nil {
+		t.Fatalf("c.CreateConnector(...): %v", err)
+	}
+
+	// Creating a connector that already exists must not adopt it.
+	if _, err := c.CreateConnector(ctx, ConnectorConfig{Name: "a", Config: config}); !IsAlreadyExists(err) {
+		t.Errorf("c.CreateConnector(...): want already exists, got %v", err)
+	}
+
+	config["tasks.max"] = "1"
+	if _, err := c.UpdateConnector(ctx, "a", config); err != nil {
+		t.Fatalf("c.UpdateConnector(...): %v", err)
+	}
+	srv.SetTaskState("a", 0, fake.StateFailed, "boom")
+
+	status, err := c.GetConnectorStatus(ctx, "a")
+	if err != nil {
+		t.Fatalf("c.GetConnectorStatus(...): %v", err)
+	}
+	want := &ConnectorStatus{
+		Name:      "a",
+		Connector: ConnectorState{State: fake.StateRunning, WorkerID: "fake-worker:8083"},
+		Tasks:     []TaskState{{ID: 0, State: fake.StateFailed, WorkerID: "fake-worker:8083", Trace: "boom"}},
+		Type:      "source",
+	}
+	if diff := cmp.Diff(want, status); diff != "" {
+		t.Errorf("c.GetConnectorStatus(...): -want, +got:\n%s", diff)
+	}
+
+	expanded, err := c.ListConnectorsExpanded(ctx)
+	if err != nil {
+		t.Fatalf("c.ListConnectorsExpanded(...): %v", err)
+	}
+	if diff := cmp.Diff(want, expanded["a"].Status); diff != "" {
+		t.Errorf("c.ListConnectorsExpanded(...): -want status, +got:\n%s", diff)
+	}
+
+	if err := c.DeleteConnector(ctx, "a"); err != nil {
+		t.Fatalf("c.DeleteConnector(...): %v", err)
+	}
+	if _, err := c.GetConnector(ctx, "a"); !IsNotFound(err) {
+		t.Errorf("c.GetConnector(...): want not found, got %v", err)
+	}
+
+	// The first request must have been answered with a rebalance error.
+	if diff := cmp.Diff(fake.Request{Method: http.MethodPost, Path: "/connectors"}, srv.Requests()[0]); diff != "" {
+		t.Errorf("srv.Requests(): -want first request, +got:\n%s", diff)
+	}
+}
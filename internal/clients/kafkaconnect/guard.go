@@ -0,0 +1,80 @@
+package kafkaconnect
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+)
+
+// A ClusterIdentity identifies a Kafka Connect cluster.
+type ClusterIdentity struct {
+	// KafkaClusterID is the ID of the Kafka cluster the Kafka Connect cluster
+	// is connected to. It is not verified if empty.
+	KafkaClusterID string
+
+	// ConnectGroup is the group ID of the Kafka Connect cluster. It is not
+	// verified if empty.
+	ConnectGroup string
+}
+
+// Verify that the supplied server info matches this identity.
+func (id ClusterIdentity) Verify(info *ServerInfo) error {
+	if id.KafkaClusterID != "" && id.KafkaClusterID != info.KafkaClusterID {
+		return &ClusterMismatchError{Field: "Kafka cluster ID", Expected: id.KafkaClusterID, Actual: info.KafkaClusterID}
+	}
+	if id.ConnectGroup != "" && id.ConnectGroup != info.GroupID {
+		return &ClusterMismatchError{Field: "Connect group", Expected: id.ConnectGroup, Actual: info.GroupID}
+	}
+	return nil
+}
+
+// A ClusterMismatchError is returned if a worker does not belong to the
+// expected cluster.
+type ClusterMismatchError struct {
+	Field    string
+	Expected string
+	Actual   string
+}
+
+func (e *ClusterMismatchError) Error() string {
+	if e.Actual == "" {
+		return fmt.Sprintf("refusing to modify Kafka Connect cluster: expected %s %q, but the worker does not report it", e.Field, e.Expected)
+	}
+	return fmt.Sprintf("refusing to modify Kafka Connect cluster: expected %s %q, got %q", e.Field, e.Expected, e.Actual)
+}
+
+// IsClusterMismatch returns true if the supplied error indicates that a
+// worker does not belong to the expected cluster.
+func IsClusterMismatch(err error) bool {
+	var e *ClusterMismatchError
+	return errors.As(err, &e)
+}
+
+// WithExpectedCluster makes the client verify the identity of the cluster
+// before any mutating request.
+func WithExpectedCluster(id ClusterIdentity) ClientOption {
+	return func(c *Client) {
+		c.expected = id
+	}
+}
+
+// verifyCluster verifies the identity of the cluster before the supplied
+// request is sent, if it is mutating and an expected identity is configured.
+func (c *Client) verifyCluster(req *http.Request) error {
+	if c.expected == (ClusterIdentity{}) || isSafe(req.Method) {
+		return nil
+	}
+	info, err := c.cachedServerInfo(req.Context())
+	if err != nil {
+		return fmt.Errorf("cannot verify Kafka Connect cluster: %w", err)
+	}
+	return c.expected.Verify(info)
+}
+
+func isSafe(method string) bool {
+	switch method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions:
+		return true
+	}
+	return false
+}
@@ -0,0 +1,80 @@
+package kafkaconnect
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestClientVerifyCluster(t *testing.T) {
+	type want struct {
+		mismatch bool
+		creates  int32
+	}
+
+	cases := map[string]struct {
+		reason   string
+		expected ClusterIdentity
+		override bool
+		want     want
+	}{
+		"NotConfigured": {
+			reason: "Requests should not be guarded if no identity is expected.",
+			want:   want{creates: 1},
+		},
+		"Match": {
+			reason:   "Mutating requests should be sent if the worker belongs to the expected cluster.",
+			expected: ClusterIdentity{KafkaClusterID: "cluster", ConnectGroup: "group"},
+			want:     want{creates: 1},
+		},
+		"KafkaClusterMismatch": {
+			reason:   "Mutating requests should be refused if the worker is connected to another Kafka cluster.",
+			expected: ClusterIdentity{KafkaClusterID: "other"},
+			want:     want{mismatch: true},
+		},
+		"ConnectGroupMismatch": {
+			reason:   "Mutating requests should be refused if the worker belongs to another Connect group.",
+			expected: ClusterIdentity{ConnectGroup: "other"},
+			want:     want{mismatch: true},
+		},
+		"OverrideMismatch": {
+			reason:   "Mutating requests should be refused if a URL override points at another Kafka cluster.",
+			expected: ClusterIdentity{KafkaClusterID: "other"},
+			override: true,
+			want:     want{mismatch: true},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			var creates atomic.Int32
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				switch {
+				case r.URL.Path == "/":
+					_, _ = w.Write([]byte(`{"version":"3.9.0","commit":"abc","kafka_cluster_id":"cluster","group_id":"group"}`))
+				case r.Method == http.MethodPost:
+					creates.Add(1)
+					w.WriteHeader(http.StatusCreated)
+					_, _ = w.Write([]byte(`{"name":"a","config":{"name":"a"},"tasks":[]}`))
+				}
+			}))
+			defer srv.Close()
+
+			c := NewClient(srv.URL, WithExpectedCluster(tc.expected))
+			if tc.override {
+				c = NewClient("http://workers.invalid", WithExpectedCluster(tc.expected), WithURL(srv.URL))
+			}
+			_, err := c.CreateConnector(context.Background(), ConnectorConfig{Name: "a"})
+			if diff := cmp.Diff(tc.want.mismatch, IsClusterMismatch(err)); diff != "" {
+				t.Errorf("\n%s\nIsClusterMismatch(...): -want, +got:\n%s\nerror: %v", tc.reason, diff, err)
+			}
+			if diff := cmp.Diff(tc.want.creates, creates.Load()); diff != "" {
+				t.Errorf("\n%s\nc.CreateConnector(...): -want creates, +got creates:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,102 @@
+package kafkaconnect
+
+import (
+	"context"
+	"sync"
+
+	"golang.org/x/time/rate"
+
+	"github.com/crossplane/provider-kafkaconnect/apis/v1alpha1"
+)
+
+// A Limiter limits the concurrency and rate of requests to a Kafka Connect
+// cluster. Limiters are shared by all clients of a ProviderConfig.
+type Limiter struct {
+	maxConcurrent     int
+	requestsPerSecond int
+
+	// sem has a slot per concurrent request. It is nil if concurrency is
+	// not limited.
+	sem chan struct{}
+
+	// rate is nil if the rate is not limited.
+	rate *rate.Limiter
+}
+
+// NewLimiter returns a limiter that allows the supplied number of concurrent
+// requests and requests per second. Zero means unlimited.
+func NewLimiter(maxConcurrent, requestsPerSecond int) *Limiter {
+	l := &Limiter{maxConcurrent: maxConcurrent, requestsPerSecond: requestsPerSecond}
+	if maxConcurrent > 0 {
+		l.sem = make(chan struct{}, maxConcurrent)
+	}
+	if requestsPerSecond > 0 {
+		l.rate = rate.NewLimiter(rate.Limit(requestsPerSecond), requestsPerSecond)
+	}
+	return l
+}
+
+// wait until a request may be sent. The returned function must be called
+// once the request completed.
+func (l *Limiter) wait(ctx context.Context) (func(), error) {
+	if l.sem != nil {
+		select {
+		case l.sem <- struct{}{}:
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+	}
+	release := func() {
+		if l.sem != nil {
+			<-l.sem
+		}
+	}
+
+	if l.rate != nil {
+		if err := l.rate.Wait(ctx); err != nil {
+			release()
+			return nil, err
+		}
+	}
+	return release, nil
+}
+
+// WithLimiter makes the client wait for the supplied limiter before sending
+// requests.
+func WithLimiter(l *Limiter) ClientOption {
+	return func(c *Client) {
+		c.limiter = l
+	}
+}
+
+var limiters = struct {
+	sync.Mutex
+	m map[string]*Limiter
+}{m: map[string]*Limiter{}}
+
+// LimiterFor returns the limiter of the supplied ProviderConfig, or nil if
+// its requests are not limited. The limiter is replaced if the limits of the
+// ProviderConfig changed.
+func LimiterFor(pc *v1alpha1.ProviderConfig) *Limiter {
+	maxConcurrent, rps := 0, 0
+	if pc.Spec.MaxConcurrentRequests != nil {
+		maxConcurrent = *pc.Spec.MaxConcurrentRequests
+	}
+	if pc.Spec.RequestsPerSecond != nil {
+		rps = *pc.Spec.RequestsPerSecond
+	}
+
+	limiters.Lock()
+	defer limiters.Unlock()
+
+	if maxConcurrent <= 0 && rps <= 0 {
+		delete(limiters.m, pc.GetName())
+		return nil
+	}
+	if l, ok := limiters.m[pc.GetName()]; ok && l.maxConcurrent == maxConcurrent && l.requestsPerSecond == rps {
+		return l
+	}
+	l := NewLimiter(maxConcurrent, rps)
+	limiters.m[pc.GetName()] = l
+	return l
+}
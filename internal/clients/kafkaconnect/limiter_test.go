@@ -0,0 +1,59 @@
+package kafkaconnect
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+func TestLimiterWait(t *testing.T) {
+	cases := map[string]struct {
+		reason        string
+		maxConcurrent int
+		rps           int
+		releaseFirst  bool
+		wantWait      bool
+	}{
+		"Unlimited": {
+			reason:   "Requests should not wait if nothing is limited.",
+			wantWait: false,
+		},
+		"Concurrency": {
+			reason:        "Requests should wait while the maximum number of requests is in flight.",
+			maxConcurrent: 1,
+			wantWait:      true,
+		},
+		"ConcurrencyReleased": {
+			reason:        "Requests should not wait once a request in flight completed.",
+			maxConcurrent: 1,
+			releaseFirst:  true,
+		},
+		"Rate": {
+			reason:       "Requests should wait once the rate is exceeded, even if earlier requests completed.",
+			rps:          1,
+			releaseFirst: true,
+			wantWait:     true,
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			l := NewLimiter(tc.maxConcurrent, tc.rps)
+
+			release, err := l.wait(context.Background())
+			if err != nil {
+				t.Fatalf("\n%s\nl.wait(...): %v", tc.reason, err)
+			}
+			if tc.releaseFirst {
+				release()
+			}
+
+			ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+			defer cancel()
+			_, err = l.wait(ctx)
+			if got := err != nil; got != tc.wantWait {
+				t.Errorf("\n%s\nl.wait(...): want wait %t, got error %v", tc.reason, tc.wantWait, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,113 @@
+package kafkaconnect
+
+import (
+	"context"
+	"errors"
+	"io"
+	"math/rand/v2"
+	"net/http"
+	"syscall"
+	"time"
+)
+
+// DefaultRetryPolicy is used by clients unless configured otherwise.
+var DefaultRetryPolicy = RetryPolicy{
+	MaxAttempts:    4,
+	InitialBackoff: 250 * time.Millisecond,
+	MaxBackoff:     5 * time.Second,
+}
+
+// A RetryPolicy configures how requests that failed transiently, e.g.
+// because a rebalance was in progress or a worker was restarting, are
+// retried. Only idempotent requests are retried as-is. Failed connector
+// creations are retried only after checking that the connector does not
+// exist.
+type RetryPolicy struct {
+	// MaxAttempts is the maximum number of attempts per request, including
+	// the first one. Values below 2 disable retries.
+	MaxAttempts int
+
+	// InitialBackoff is the wait before the first retry. It doubles with
+	// every further retry.
+	InitialBackoff time.Duration
+
+	// MaxBackoff bounds the wait between two attempts.
+	MaxBackoff time.Duration
+}
+
+// WithRetryPolicy sets the policy used to retry transient errors
+func WithRetryPolicy(p RetryPolicy) ClientOption {
+	return func(c *Client) {
+		c.retry = p
+	}
+}
+
+// backoff returns the wait before the supplied retry, with jitter applied to
+// the upper half of the exponential backoff.
+func (p RetryPolicy) backoff(retry int) time.Duration {
+	d := p.InitialBackoff
+	for i := 1; i < retry && d < p.MaxBackoff; i++ {
+		d *= 2
+	}
+	if d > p.MaxBackoff {
+		d = p.MaxBackoff
+	}
+	if d <= 0 {
+		return 0
+	}
+	return d/2 + rand.N(d/2+1) //nolint:gosec // Jitter does not need a secure random source.
+}
+
+func (p RetryPolicy) wait(ctx context.Context, retry int) error {
+	t := time.NewTimer(p.backoff(retry))
+	defer t.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-t.C:
+		return nil
+	}
+}
+
+// IsRetryable returns true if the supplied error is transient. Kafka Connect
+// answers 409 while a rebalance is in progress, followers answer 500 if they
+// cannot forward a request to the leader, and workers answer 5xx or reset
+// connections during rolling restarts. A 409 answered because a connector
+// already exists is not transient.
+func IsRetryable(err error) bool {
+	var apiErr *APIError
+	if errors.As(err, &apiErr) {
+		switch apiErr.StatusCode {
+		case http.StatusConflict:
+			return !IsAlreadyExists(apiErr)
+		case http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+			return true
+		}
+		return isForwardingError(apiErr)
+	}
+	return errors.Is(err, syscall.ECONNRESET) ||
+		errors.Is(err, syscall.ECONNREFUSED) ||
+		errors.Is(err, io.ErrUnexpectedEOF) ||
+		errors.Is(err, io.EOF)
+}
+
+func isIdempotent(method string) bool {
+	switch method {
+	case http.MethodGet, http.MethodHead, http.MethodPut, http.MethodDelete, http.MethodOptions:
+		return true
+	}
+	return false
+}
+
+// rewind returns a copy of the supplied request that can be sent again.
+func rewind(req *http.Request) (*http.Request, error) {
+	r := req.Clone(req.Context())
+	if req.GetBody != nil {
+		body, err := req.GetBody()
+		if err != nil {
+			return nil, err
+		}
+		r.Body = body
+	}
+	return r, nil
+}
@@ -0,0 +1,167 @@
+package kafkaconnect
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+var testRetryPolicy = RetryPolicy{MaxAttempts: 3, InitialBackoff: time.Millisecond, MaxBackoff: time.Millisecond}
+
+// A flakyServer answers each request with the next of the supplied status
+// codes, and with 200 OK once they are exhausted.
+func flakyServer(t *testing.T, codes []int, body string) (*httptest.Server, *atomic.Int32) {
+	t.Helper()
+	var calls atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		i := int(calls.Add(1)) - 1
+		if i < len(codes) {
+			w.WriteHeader(codes[i])
+			_, _ = w.Write([]byte(`{"error_code":` + strconv.Itoa(codes[i]) + `,"message":"` + http.StatusText(codes[i]) + `"}`))
+			return
+		}
+		_, _ = w.Write([]byte(body))
+	}))
+	t.Cleanup(srv.Close)
+	return srv, &calls
+}
+
+func TestDoRequestRetry(t *testing.T) {
+	type want struct {
+		calls int32
+		err   bool
+	}
+
+	cases := map[string]struct {
+		reason string
+		codes  []int
+		want   want
+	}{
+		"RebalanceInProgress": {
+			reason: "GET requests should be retried while a rebalance is in progress.",
+			codes:  []int{http.StatusConflict, http.StatusServiceUnavailable},
+			want:   want{calls: 3},
+		},
+		"AttemptsExhausted": {
+			reason: "Requests should fail once the maximum number of attempts is reached.",
+			codes:  []int{http.StatusBadGateway, http.StatusGatewayTimeout, http.StatusServiceUnavailable},
+			want:   want{calls: 3, err: true},
+		},
+		"NotRetryable": {
+			reason: "Requests should not be retried on permanent errors.",
+			codes:  []int{http.StatusInternalServerError},
+			want:   want{calls: 1, err: true},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			srv, calls := flakyServer(t, tc.codes, `{"name":"a","config":{},"tasks":[]}`)
+			c := NewClient(srv.URL, WithRetryPolicy(testRetryPolicy))
+
+			_, err := c.GetConnector(context.Background(), "a")
+			if (err != nil) != tc.want.err {
+				t.Errorf("\n%s\nc.GetConnector(...): want error %t, got %v", tc.reason, tc.want.err, err)
+			}
+			if diff := cmp.Diff(tc.want.calls, calls.Load()); diff != "" {
+				t.Errorf("\n%s\nc.GetConnector(...): -want calls, +got calls:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestCreateConnectorRetry(t *testing.T) {
+	type want struct {
+		creates       int32
+		alreadyExists bool
+	}
+
+	cases := map[string]struct {
+		reason string
+		status int
+		body   string
+		exists bool
+		want   want
+	}{
+		"CreatedDespiteError": {
+			reason: "A create that failed transiently should not be retried if the connector exists.",
+			status: http.StatusServiceUnavailable,
+			exists: true,
+			want:   want{creates: 1},
+		},
+		"NotCreated": {
+			reason: "A create that failed transiently should be retried if the connector does not exist.",
+			status: http.StatusServiceUnavailable,
+			want:   want{creates: 2},
+		},
+		"Rebalancing": {
+			reason: "A create that conflicted with a rebalance should be retried.",
+			status: http.StatusConflict,
+			body:   `{"error_code":409,"message":"Cannot complete request momentarily due to stale configuration (typically caused by a concurrent config change)"}`,
+			want:   want{creates: 2},
+		},
+		"AlreadyExists": {
+			reason: "A create that conflicted with an existing connector should fail rather than return the existing connector.",
+			status: http.StatusConflict,
+			body:   `{"error_code":409,"message":"Connector a already exists"}`,
+			exists: true,
+			want:   want{creates: 1, alreadyExists: true},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			var creates atomic.Int32
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				switch r.Method {
+				case http.MethodPost:
+					if creates.Add(1) == 1 {
+						w.WriteHeader(tc.status)
+						_, _ = w.Write([]byte(tc.body))
+						return
+					}
+					w.WriteHeader(http.StatusCreated)
+				case http.MethodGet:
+					if !tc.exists {
+						w.WriteHeader(http.StatusNotFound)
+						return
+					}
+				}
+				_, _ = w.Write([]byte(`{"name":"a","config":{"name":"a"},"tasks":[]}`))
+			}))
+			defer srv.Close()
+
+			c := NewClient(srv.URL, WithRetryPolicy(testRetryPolicy))
+			info, err := c.CreateConnector(context.Background(), ConnectorConfig{Name: "a"})
+			if diff := cmp.Diff(tc.want.alreadyExists, IsAlreadyExists(err)); diff != "" {
+				t.Errorf("\n%s\nIsAlreadyExists(c.CreateConnector(...)): -want, +got:\n%s", tc.reason, diff)
+			}
+			if !tc.want.alreadyExists {
+				if err != nil {
+					t.Fatalf("\n%s\nc.CreateConnector(...): %v", tc.reason, err)
+				}
+				if diff := cmp.Diff("a", info.Name); diff != "" {
+					t.Errorf("\n%s\nc.CreateConnector(...): -want, +got:\n%s", tc.reason, diff)
+				}
+			}
+			if diff := cmp.Diff(tc.want.creates, creates.Load()); diff != "" {
+				t.Errorf("\n%s\nc.CreateConnector(...): -want creates, +got creates:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestRetryPolicyBackoff(t *testing.T) {
+	p := RetryPolicy{InitialBackoff: 100 * time.Millisecond, MaxBackoff: time.Second}
+	for retry, max := range map[int]time.Duration{1: 100 * time.Millisecond, 2: 200 * time.Millisecond, 5: time.Second, 10: time.Second} {
+		if got := p.backoff(retry); got < max/2 || got > max {
+			t.Errorf("p.backoff(%d): want between %s and %s, got %s", retry, max/2, max, got)
+		}
+	}
+}
@@ -0,0 +1,106 @@
+package kafkaconnect
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"slices"
+	"strconv"
+
+	corev1 "k8s.io/api/core/v1"
+	discoveryv1 "k8s.io/api/discovery/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/crossplane/provider-kafkaconnect/apis/v1alpha1"
+)
+
+// WithWorkers makes the client fail over between the supplied worker URLs,
+// e.g. resolved from a Service. It must follow WithProviderConfig.
+func WithWorkers(urls ...string) ClientOption {
+	return func(c *Client) {
+		if len(urls) == 0 {
+			return
+		}
+		c.baseURL = urls[0]
+		c.endpoints = EndpointsFor(c.providerConfig, urls)
+	}
+}
+
+// ResolveServiceRef resolves the supplied Service reference to worker URLs:
+// to the Service's DNS name, or to the addresses of its ready endpoints.
+func ResolveServiceRef(ctx context.Context, kube client.Reader, ref *v1alpha1.ServiceReference) ([]string, error) {
+	svc := &corev1.Service{}
+	if err := kube.Get(ctx, types.NamespacedName{Namespace: ref.Namespace, Name: ref.Name}, svc); err != nil {
+		return nil, fmt.Errorf("cannot get Service %s/%s: %w", ref.Namespace, ref.Name, err)
+	}
+	port, err := servicePort(svc, ref.Port)
+	if err != nil {
+		return nil, err
+	}
+	scheme := ref.Scheme
+	if scheme == "" {
+		scheme = "http"
+	}
+
+	if !ref.ResolveEndpoints {
+		host := svc.GetName() + "." + svc.GetNamespace() + ".svc"
+		return []string{scheme + "://" + net.JoinHostPort(host, strconv.Itoa(int(port.Port)))}, nil
+	}
+
+	l := &discoveryv1.EndpointSliceList{}
+	if err := kube.List(ctx, l, client.InNamespace(svc.GetNamespace()), client.MatchingLabels{discoveryv1.LabelServiceName: svc.GetName()}); err != nil {
+		return nil, fmt.Errorf("cannot list endpoints of Service %s/%s: %w", ref.Namespace, ref.Name, err)
+	}
+
+	var urls []string
+	for _, es := range l.Items {
+		number, ok := endpointPort(es, port.Name)
+		if !ok {
+			continue
+		}
+		for _, e := range es.Endpoints {
+			if e.Conditions.Ready != nil && !*e.Conditions.Ready {
+				continue
+			}
+			for _, addr := range e.Addresses {
+				urls = append(urls, scheme+"://"+net.JoinHostPort(addr, strconv.Itoa(int(number))))
+			}
+		}
+	}
+	if len(urls) == 0 {
+		return nil, fmt.Errorf("Service %s/%s has no ready endpoints", ref.Namespace, ref.Name)
+	}
+
+	// Sort the URLs so that the endpoint set of the ProviderConfig is only
+	// replaced if the endpoints actually changed.
+	slices.Sort(urls)
+	return slices.Compact(urls), nil
+}
+
+func servicePort(svc *corev1.Service, name string) (corev1.ServicePort, error) {
+	if name == "" {
+		if len(svc.Spec.Ports) != 1 {
+			return corev1.ServicePort{}, fmt.Errorf("Service %s/%s has %d ports, a port name is required", svc.GetNamespace(), svc.GetName(), len(svc.Spec.Ports))
+		}
+		return svc.Spec.Ports[0], nil
+	}
+	for _, p := range svc.Spec.Ports {
+		if p.Name == name {
+			return p, nil
+		}
+	}
+	return corev1.ServicePort{}, errors.New("Service " + svc.GetNamespace() + "/" + svc.GetName() + " has no port named " + name)
+}
+
+// endpointPort returns the number of the named port of the supplied slice.
+// Slice ports are named after the Service ports they implement.
+func endpointPort(es discoveryv1.EndpointSlice, name string) (int32, bool) {
+	for _, p := range es.Ports {
+		if p.Port != nil && (p.Name == nil && name == "" || p.Name != nil && *p.Name == name) {
+			return *p.Port, true
+		}
+	}
+	return 0, false
+}
@@ -0,0 +1,82 @@
+package kafkaconnect
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	corev1 "k8s.io/api/core/v1"
+	discoveryv1 "k8s.io/api/discovery/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"github.com/crossplane/provider-kafkaconnect/apis/v1alpha1"
+)
+
+func TestResolveServiceRef(t *testing.T) {
+	svc := &corev1.Service{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "kafka", Name: "connect"},
+		Spec: corev1.ServiceSpec{Ports: []corev1.ServicePort{
+			{Name: "rest", Port: 8083},
+			{Name: "metrics", Port: 9404},
+		}},
+	}
+	es := &discoveryv1.EndpointSlice{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "kafka", Name: "connect-abc", Labels: map[string]string{discoveryv1.LabelServiceName: "connect"}},
+		Ports: []discoveryv1.EndpointPort{
+			{Name: ptr.To("metrics"), Port: ptr.To[int32](9404)},
+			{Name: ptr.To("rest"), Port: ptr.To[int32](18083)},
+		},
+		Endpoints: []discoveryv1.Endpoint{
+			{Addresses: []string{"10.0.0.2"}, Conditions: discoveryv1.EndpointConditions{Ready: ptr.To(true)}},
+			{Addresses: []string{"10.0.0.1"}},
+			{Addresses: []string{"10.0.0.3"}, Conditions: discoveryv1.EndpointConditions{Ready: ptr.To(false)}},
+		},
+	}
+
+	type want struct {
+		urls []string
+		err  bool
+	}
+
+	cases := map[string]struct {
+		reason string
+		ref    *v1alpha1.ServiceReference
+		want   want
+	}{
+		"DNSName": {
+			reason: "A Service should resolve to its DNS name and the named port.",
+			ref:    &v1alpha1.ServiceReference{Namespace: "kafka", Name: "connect", Port: "rest", Scheme: "https"},
+			want:   want{urls: []string{"https://connect.kafka.svc:8083"}},
+		},
+		"PortRequired": {
+			reason: "A port name should be required if the Service has several ports.",
+			ref:    &v1alpha1.ServiceReference{Namespace: "kafka", Name: "connect"},
+			want:   want{err: true},
+		},
+		"Endpoints": {
+			reason: "A Service should resolve to its ready endpoints if requested.",
+			ref:    &v1alpha1.ServiceReference{Namespace: "kafka", Name: "connect", Port: "rest", ResolveEndpoints: true},
+			want:   want{urls: []string{"http://10.0.0.1:18083", "http://10.0.0.2:18083"}},
+		},
+		"NotFound": {
+			reason: "A missing Service should return an error.",
+			ref:    &v1alpha1.ServiceReference{Namespace: "kafka", Name: "missing"},
+			want:   want{err: true},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			kube := fake.NewClientBuilder().WithObjects(svc, es).Build()
+			got, err := ResolveServiceRef(context.Background(), kube, tc.ref)
+			if (err != nil) != tc.want.err {
+				t.Errorf("\n%s\nResolveServiceRef(...): want error %t, got %v", tc.reason, tc.want.err, err)
+			}
+			if diff := cmp.Diff(tc.want.urls, got); diff != "" {
+				t.Errorf("\n%s\nResolveServiceRef(...): -want, +got:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
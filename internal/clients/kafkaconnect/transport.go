@@ -0,0 +1,71 @@
+package kafkaconnect
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"golang.org/x/net/http/httpproxy"
+
+	"github.com/crossplane/provider-kafkaconnect/apis/v1alpha1"
+)
+
+const (
+	defaultTimeout   = 30 * time.Second
+	defaultKeepAlive = 30 * time.Second
+)
+
+// configureTransport applies the supplied transport configuration to an HTTP
+// client and its transport. Unset fields keep the defaults of
+// http.DefaultTransport.
+func configureTransport(hc *http.Client, t *http.Transport, cfg *v1alpha1.TransportConfig) error {
+	if cfg == nil {
+		return nil
+	}
+
+	if cfg.Timeout != nil {
+		hc.Timeout = cfg.Timeout.Duration
+	}
+	if cfg.DialTimeout != nil {
+		t.DialContext = (&net.Dialer{Timeout: cfg.DialTimeout.Duration, KeepAlive: defaultKeepAlive}).DialContext
+	}
+	if cfg.TLSHandshakeTimeout != nil {
+		t.TLSHandshakeTimeout = cfg.TLSHandshakeTimeout.Duration
+	}
+	if cfg.ResponseHeaderTimeout != nil {
+		t.ResponseHeaderTimeout = cfg.ResponseHeaderTimeout.Duration
+	}
+	if cfg.IdleConnTimeout != nil {
+		t.IdleConnTimeout = cfg.IdleConnTimeout.Duration
+	}
+	if cfg.MaxIdleConns != nil {
+		t.MaxIdleConns = *cfg.MaxIdleConns
+	}
+	if cfg.MaxIdleConnsPerHost != nil {
+		t.MaxIdleConnsPerHost = *cfg.MaxIdleConnsPerHost
+	}
+	if cfg.MaxConnsPerHost != nil {
+		t.MaxConnsPerHost = *cfg.MaxConnsPerHost
+	}
+
+	if cfg.ProxyURL == "" {
+		if len(cfg.NoProxy) > 0 {
+			return errors.New("noProxy requires a proxy URL")
+		}
+		return nil
+	}
+	if _, err := url.Parse(cfg.ProxyURL); err != nil {
+		return fmt.Errorf("invalid proxy URL: %w", err)
+	}
+	proxy := (&httpproxy.Config{
+		HTTPProxy:  cfg.ProxyURL,
+		HTTPSProxy: cfg.ProxyURL,
+		NoProxy:    strings.Join(cfg.NoProxy, ","),
+	}).ProxyFunc()
+	t.Proxy = func(req *http.Request) (*url.URL, error) { return proxy(req.URL) }
+	return nil
+}
@@ -0,0 +1,83 @@
+package kafkaconnect
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+
+	"github.com/crossplane/provider-kafkaconnect/apis/v1alpha1"
+)
+
+func TestConfigureTransport(t *testing.T) {
+	var proxied atomic.Int32
+	proxy := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		proxied.Add(1)
+		_, _ = w.Write([]byte(`{"version":"3.9.0"}`))
+	}))
+	defer proxy.Close()
+
+	direct := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		_, _ = w.Write([]byte(`{"version":"3.9.0"}`))
+	}))
+	defer direct.Close()
+
+	cases := map[string]struct {
+		reason  string
+		url     string
+		noProxy []string
+		proxied int32
+	}{
+		"Proxied": {
+			reason:  "Requests should be sent through the configured proxy.",
+			url:     "http://connect.example.com:8083",
+			proxied: 1,
+		},
+		"NoProxy": {
+			reason:  "Requests to hosts excluded from the proxy should be sent directly.",
+			url:     direct.URL,
+			noProxy: []string{"127.0.0.1"},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			proxied.Store(0)
+			hc, err := newHTTPClient(nil, &v1alpha1.TransportConfig{
+				Timeout:         &metav1.Duration{Duration: time.Minute},
+				ProxyURL:        proxy.URL,
+				NoProxy:         tc.noProxy,
+				MaxConnsPerHost: ptr.To(2),
+			})
+			if err != nil {
+				t.Fatalf("\n%s\nnewHTTPClient(...): %v", tc.reason, err)
+			}
+			if diff := cmp.Diff(time.Minute, hc.Timeout); diff != "" {
+				t.Errorf("\n%s\nnewHTTPClient(...): -want timeout, +got:\n%s", tc.reason, diff)
+			}
+			if diff := cmp.Diff(2, hc.Transport.(*http.Transport).MaxConnsPerHost); diff != "" {
+				t.Errorf("\n%s\nnewHTTPClient(...): -want max conns per host, +got:\n%s", tc.reason, diff)
+			}
+
+			c := NewClient(tc.url, WithHTTPClient(hc))
+			if _, err := c.GetServerInfo(context.Background()); err != nil {
+				t.Fatalf("\n%s\nc.GetServerInfo(...): %v", tc.reason, err)
+			}
+			if diff := cmp.Diff(tc.proxied, proxied.Load()); diff != "" {
+				t.Errorf("\n%s\nc.GetServerInfo(...): -want proxied, +got:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestConfigureTransportNoProxyWithoutProxy(t *testing.T) {
+	if _, err := newHTTPClient(nil, &v1alpha1.TransportConfig{NoProxy: []string{"example.com"}}); err == nil {
+		t.Errorf("newHTTPClient(...): want error for noProxy without a proxy URL")
+	}
+}
@@ -0,0 +1,86 @@
+package kafkaconnect
+
+import (
+	"fmt"
+	"strings"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
+
+	nsv1alpha1 "github.com/crossplane/provider-kafkaconnect/apis/namespaced/v1alpha1"
+	"github.com/crossplane/provider-kafkaconnect/apis/v1alpha1"
+)
+
+// ProviderConfigView returns a cluster scoped view of the supplied namespaced
+// ProviderConfig or ClusterProviderConfig, which can be passed to the
+// functions of this package. The view is named so that the state kept per
+// ProviderConfig name, such as circuit breakers, is not shared with a
+// ProviderConfig of the same name in another namespace or of another kind.
+// Changes to the status of the view must be copied back. ProviderConfigs are
+// returned as is, and other provider configs as nil.
+func ProviderConfigView(pc resource.ProviderConfig) *v1alpha1.ProviderConfig {
+	switch pc := pc.(type) {
+	case *v1alpha1.ProviderConfig:
+		return pc
+	case *nsv1alpha1.ProviderConfig:
+		return view(pc.GetNamespace()+"/"+pc.GetName(), pc.ObjectMeta, pc.Spec, pc.Status)
+	case *nsv1alpha1.ClusterProviderConfig:
+		// Namespaces are lowercase, so this cannot be the name of the view
+		// of a ProviderConfig.
+		return view(nsv1alpha1.ClusterProviderConfigKind+"/"+pc.GetName(), pc.ObjectMeta, pc.Spec, pc.Status)
+	}
+	return nil
+}
+
+// CheckNamespace returns an error if the supplied namespaced ProviderConfig
+// uses credentials or workers outside of its namespace. Other credential
+// sources, such as the environment of the provider, are owned by the
+// platform. It must be called before the credentials or workers of a
+// namespaced ProviderConfig are read.
+func CheckNamespace(pc *nsv1alpha1.ProviderConfig) error {
+	cd := pc.Spec.Credentials
+	switch cd.Source {
+	case xpv1.CredentialsSourceNone:
+	case xpv1.CredentialsSourceSecret:
+		if ref := cd.SecretRef; ref != nil && ref.Namespace != pc.GetNamespace() {
+			return fmt.Errorf("ProviderConfig %s/%s cannot use credentials from Secret %s/%s in another namespace", pc.GetNamespace(), pc.GetName(), ref.Namespace, ref.Name)
+		}
+	default:
+		return fmt.Errorf("ProviderConfig %s/%s cannot use %s credentials", pc.GetNamespace(), pc.GetName(), cd.Source)
+	}
+	if ref := pc.Spec.ServiceRef; ref != nil && ref.Namespace != pc.GetNamespace() {
+		return fmt.Errorf("ProviderConfig %s/%s cannot use Service %s/%s in another namespace", pc.GetNamespace(), pc.GetName(), ref.Namespace, ref.Name)
+	}
+	return nil
+}
+
+func view(name string, om metav1.ObjectMeta, spec v1alpha1.ProviderConfigSpec, status v1alpha1.ProviderConfigStatus) *v1alpha1.ProviderConfig {
+	return &v1alpha1.ProviderConfig{
+		ObjectMeta: metav1.ObjectMeta{Name: name, UID: om.GetUID(), Generation: om.GetGeneration()},
+		Spec:       *spec.DeepCopy(),
+		Status:     *status.DeepCopy(),
+	}
+}
+
+// Forget drops the state kept for the named ProviderConfig or view, i.e. its
+// circuit breakers, endpoint set and limiter. It should be called once the
+// ProviderConfig was deleted.
+func Forget(providerConfig string) {
+	breakers.Lock()
+	for name := range breakers.m {
+		if name == providerConfig || strings.HasPrefix(name, providerConfig+" ") {
+			delete(breakers.m, name)
+		}
+	}
+	breakers.Unlock()
+
+	endpointSets.Lock()
+	delete(endpointSets.sets, providerConfig)
+	endpointSets.Unlock()
+
+	limiters.Lock()
+	delete(limiters.m, providerConfig)
+	limiters.Unlock()
+}
@@ -0,0 +1,105 @@
+package kafkaconnect
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"k8s.io/utils/ptr"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+
+	nsv1alpha1 "github.com/crossplane/provider-kafkaconnect/apis/namespaced/v1alpha1"
+	"github.com/crossplane/provider-kafkaconnect/apis/v1alpha1"
+)
+
+func TestCheckNamespace(t *testing.T) {
+	secret := func(namespace string) v1alpha1.ProviderCredentials {
+		return v1alpha1.ProviderCredentials{
+			Source: xpv1.CredentialsSourceSecret,
+			CommonCredentialSelectors: xpv1.CommonCredentialSelectors{SecretRef: &xpv1.SecretKeySelector{
+				SecretReference: xpv1.SecretReference{Namespace: namespace, Name: "creds"},
+				Key:             "credentials",
+			}},
+		}
+	}
+
+	cases := map[string]struct {
+		reason string
+		spec   v1alpha1.ProviderConfigSpec
+		want   string
+	}{
+		"SameNamespace": {
+			reason: "A ProviderConfig may use Secrets and Services in its namespace.",
+			spec: v1alpha1.ProviderConfigSpec{
+				Credentials: secret("team"),
+				ServiceRef:  &v1alpha1.ServiceReference{Namespace: "team", Name: "connect"},
+			},
+		},
+		"SecretInOtherNamespace": {
+			reason: "A ProviderConfig should not use credentials from another namespace.",
+			spec:   v1alpha1.ProviderConfigSpec{Credentials: secret("crossplane-system")},
+			want:   "ProviderConfig team/pc cannot use credentials from Secret crossplane-system/creds in another namespace",
+		},
+		"ServiceInOtherNamespace": {
+			reason: "A ProviderConfig should not use workers behind a Service in another namespace.",
+			spec: v1alpha1.ProviderConfigSpec{
+				Credentials: v1alpha1.ProviderCredentials{Source: xpv1.CredentialsSourceNone},
+				ServiceRef:  &v1alpha1.ServiceReference{Namespace: "kafka", Name: "connect"},
+			},
+			want: "ProviderConfig team/pc cannot use Service kafka/connect in another namespace",
+		},
+		"EnvironmentCredentials": {
+			reason: "A ProviderConfig should not use credentials from the environment of the provider.",
+			spec:   v1alpha1.ProviderConfigSpec{Credentials: v1alpha1.ProviderCredentials{Source: xpv1.CredentialsSourceEnvironment}},
+			want:   "ProviderConfig team/pc cannot use Environment credentials",
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			pc := &nsv1alpha1.ProviderConfig{Spec: tc.spec}
+			pc.SetNamespace("team")
+			pc.SetName("pc")
+
+			var got string
+			if err := CheckNamespace(pc); err != nil {
+				got = err.Error()
+			}
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("\n%s\nCheckNamespace(...): -want error, +got error:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestForget(t *testing.T) {
+	pc := func(name string) *v1alpha1.ProviderConfig {
+		pc := &v1alpha1.ProviderConfig{Spec: v1alpha1.ProviderConfigSpec{RequestsPerSecond: ptr.To(10)}}
+		pc.SetName(name)
+		return pc
+	}
+	urls := []string{"http://worker:8083"}
+
+	breaker, override := BreakerFor("forget"), BreakerFor(OverrideBreaker("forget", "http://override:8083"))
+	endpoints, limiter := EndpointsFor("forget", urls), LimiterFor(pc("forget"))
+	kept := BreakerFor("forget-not")
+
+	Forget("forget")
+
+	if BreakerFor("forget") == breaker {
+		t.Errorf("BreakerFor(...): want a new breaker for a forgotten ProviderConfig")
+	}
+	if BreakerFor(OverrideBreaker("forget", "http://override:8083")) == override {
+		t.Errorf("BreakerFor(...): want a new breaker for an override URL of a forgotten ProviderConfig")
+	}
+	if EndpointsFor("forget", urls) == endpoints {
+		t.Errorf("EndpointsFor(...): want a new endpoint set for a forgotten ProviderConfig")
+	}
+	if LimiterFor(pc("forget")) == limiter {
+		t.Errorf("LimiterFor(...): want a new limiter for a forgotten ProviderConfig")
+	}
+	if BreakerFor("forget-not") != kept {
+		t.Errorf("BreakerFor(...): want the breaker of another ProviderConfig to be kept")
+	}
+	Forget("forget")
+}
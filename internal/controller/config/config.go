@@ -3,16 +3,16 @@ package config
 import (
     ctrl "sigs.k8s.io/controller-runtime"
 
-    "github.com/crossplane/crossplane-runtime/pkg/controller"
     "github.com/crossplane/crossplane-runtime/pkg/event"
     "github.com/crossplane/crossplane-runtime/pkg/reconciler/providerconfig"
     "github.com/crossplane/crossplane-runtime/pkg/resource"
 
     "github.com/crossplane/provider-kafkaconnect/apis/v1alpha1"
+    "github.com/crossplane/provider-kafkaconnect/internal/options"
 )
 
 // Setup adds a controller that reconciles ProviderConfigs.
-func Setup(mgr ctrl.Manager, o controller.Options) error {
+func Setup(mgr ctrl.Manager, o options.Options) error {
     name := providerconfig.ControllerName(v1alpha1.ProviderConfigGroupKind)
 
     of := resource.ProviderConfigKinds{
@@ -0,0 +1,185 @@
+/*
+Copyright 2025 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package config
+
+import (
+	"context"
+	"time"
+
+	"github.com/pkg/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/event"
+	"github.com/crossplane/crossplane-runtime/pkg/logging"
+	"github.com/crossplane/crossplane-runtime/pkg/meta"
+	"github.com/crossplane/crossplane-runtime/pkg/reconciler/providerconfig"
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
+
+	nsv1alpha1 "github.com/crossplane/provider-kafkaconnect/apis/namespaced/v1alpha1"
+	"github.com/crossplane/provider-kafkaconnect/internal/options"
+)
+
+const (
+	finalizer = "in-use.crossplane.io"
+	shortWait = 30 * time.Second
+
+	errListPCUs  = "cannot list ProviderConfigUsages"
+	errDeletePCU = "cannot delete ProviderConfigUsage"
+	errUpdate    = "cannot update ProviderConfig"
+
+	reasonAccount event.Reason = "UsageAccounting"
+)
+
+// SetupNamespaced adds controllers that account for the usages of the
+// ProviderConfigs and ClusterProviderConfigs of namespaced resources, and
+// block their deletion while they are used.
+//
+// The ProviderConfig reconciler of crossplane-runtime is not used because it
+// matches usages by name across all namespaces, so it cannot tell a
+// ProviderConfig from a ClusterProviderConfig or a ProviderConfig of the
+// same name in another namespace.
+func SetupNamespaced(mgr ctrl.Manager, o options.Options) error {
+	for kind, newConfig := range map[string]func() resource.ProviderConfig{
+		nsv1alpha1.ProviderConfigKind:        func() resource.ProviderConfig { return &nsv1alpha1.ProviderConfig{} },
+		nsv1alpha1.ClusterProviderConfigKind: func() resource.ProviderConfig { return &nsv1alpha1.ClusterProviderConfig{} },
+	} {
+		name := providerconfig.ControllerName(kind + "." + nsv1alpha1.Group)
+		r := &usageReconciler{
+			kube:      mgr.GetClient(),
+			log:       o.Logger.WithValues("controller", name),
+			record:    event.NewAPIRecorder(mgr.GetEventRecorderFor(name)),
+			kind:      kind,
+			newConfig: newConfig,
+		}
+
+		err := ctrl.NewControllerManagedBy(mgr).
+			Named(name).
+			WithOptions(o.ForControllerRuntime()).
+			For(newConfig()).
+			Watches(&nsv1alpha1.ProviderConfigUsage{}, handler.EnqueueRequestsFromMapFunc(r.used)).
+			Complete(r)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// A usageReconciler accounts for the usages of a ProviderConfig or
+// ClusterProviderConfig.
+type usageReconciler struct {
+	kube   client.Client
+	log    logging.Logger
+	record event.Recorder
+
+	// kind of the provider configs reconciled.
+	kind      string
+	newConfig func() resource.ProviderConfig
+}
+
+// used enqueues the provider config of a ProviderConfigUsage, if it is of the
+// reconciled kind.
+func (r *usageReconciler) used(_ context.Context, obj client.Object) []reconcile.Request {
+	pcu, ok := obj.(*nsv1alpha1.ProviderConfigUsage)
+	if !ok || pcu.ProviderConfigReference.Kind != r.kind {
+		return nil
+	}
+	nn := types.NamespacedName{Name: pcu.ProviderConfigReference.Name}
+	if r.kind == nsv1alpha1.ProviderConfigKind {
+		nn.Namespace = pcu.GetNamespace()
+	}
+	return []reconcile.Request{{NamespacedName: nn}}
+}
+
+// Reconcile the usages of a provider config. A ProviderConfig is only used by
+// resources in its namespace, and a ClusterProviderConfig by resources in any
+// namespace.
+func (r *usageReconciler) Reconcile(ctx context.Context, req reconcile.Request) (reconcile.Result, error) {
+	log := r.log.WithValues("request", req)
+
+	pc := r.newConfig()
+	if err := r.kube.Get(ctx, req.NamespacedName, pc); err != nil {
+		log.Debug(errGetPC, "error", err)
+		return reconcile.Result{}, errors.Wrap(resource.IgnoreNotFound(err), errGetPC)
+	}
+
+	l := &nsv1alpha1.ProviderConfigUsageList{}
+	opts := []client.ListOption{client.MatchingLabels{
+		xpv1.LabelKeyProviderName:             pc.GetName(),
+		nsv1alpha1.LabelKeyProviderConfigKind: r.kind,
+	}}
+	if pc.GetNamespace() != "" {
+		opts = append(opts, client.InNamespace(pc.GetNamespace()))
+	}
+	if err := r.kube.List(ctx, l, opts...); err != nil {
+		log.Debug(errListPCUs, "error", err)
+		r.record.Event(pc, event.Warning(reasonAccount, errors.Wrap(err, errListPCUs)))
+		return reconcile.Result{RequeueAfter: shortWait}, nil
+	}
+
+	users := int64(len(l.Items))
+	for i := range l.Items {
+		pcu := &l.Items[i]
+		if metav1.GetControllerOf(pcu) != nil {
+			continue
+		}
+		// Usages without a controller are stale, e.g. restored from a
+		// backup. They are recreated when their resource connects again.
+		if err := r.kube.Delete(ctx, pcu); resource.IgnoreNotFound(err) != nil {
+			log.Debug(errDeletePCU, "error", err)
+			r.record.Event(pc, event.Warning(reasonAccount, errors.Wrap(err, errDeletePCU)))
+			return reconcile.Result{RequeueAfter: shortWait}, nil
+		}
+		users--
+	}
+
+	if meta.WasDeleted(pc) {
+		if users > 0 {
+			msg := "Blocking deletion while usages still exist"
+			log.Debug(msg, "usages", users)
+			r.record.Event(pc, event.Warning(reasonAccount, errors.New(msg)))
+
+			// The usages are watched, so the provider config is requeued
+			// when they are deleted.
+			pc.SetUsers(users)
+			pc.SetConditions(providerconfig.Terminating().WithMessage(msg))
+			return reconcile.Result{}, errors.Wrap(r.kube.Status().Update(ctx, pc), errUpdateStatus)
+		}
+
+		meta.RemoveFinalizer(pc, finalizer)
+		if err := r.kube.Update(ctx, pc); err != nil {
+			log.Debug(errUpdate, "error", err)
+			return reconcile.Result{RequeueAfter: shortWait}, nil
+		}
+		return reconcile.Result{}, nil
+	}
+
+	meta.AddFinalizer(pc, finalizer)
+	if err := r.kube.Update(ctx, pc); err != nil {
+		log.Debug(errUpdate, "error", err)
+		return reconcile.Result{RequeueAfter: shortWait}, nil
+	}
+
+	pc.SetUsers(users)
+	return reconcile.Result{}, errors.Wrap(r.kube.Status().Update(ctx, pc), errUpdateStatus)
+}
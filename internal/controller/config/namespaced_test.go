@@ -0,0 +1,237 @@
+/*
+Copyright 2025 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package config
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	"github.com/pkg/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/event"
+	"github.com/crossplane/crossplane-runtime/pkg/logging"
+	"github.com/crossplane/crossplane-runtime/pkg/reconciler/providerconfig"
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+
+	nsv1alpha1 "github.com/crossplane/provider-kafkaconnect/apis/namespaced/v1alpha1"
+)
+
+func TestUsageReconcile(t *testing.T) {
+	errBoom := errors.New("boom")
+	controller := true
+	used := nsv1alpha1.ProviderConfigUsage{ObjectMeta: metav1.ObjectMeta{
+		OwnerReferences: []metav1.OwnerReference{{Name: "connector", Controller: &controller}},
+	}}
+
+	type want struct {
+		result reconcile.Result
+		err    error
+		pc     *nsv1alpha1.ProviderConfig
+		opts   []client.ListOption
+	}
+
+	cases := map[string]struct {
+		reason  string
+		deleted bool
+		list    test.MockListFn
+		want    want
+	}{
+		"Used": {
+			reason: "A used ProviderConfig should get a finalizer and count its usages in its namespace.",
+			list:   listUsages(used, used),
+			want: want{
+				pc: func() *nsv1alpha1.ProviderConfig {
+					pc := providerConfig(false)
+					pc.SetFinalizers([]string{finalizer})
+					pc.SetUsers(2)
+					return pc
+				}(),
+				opts: []client.ListOption{
+					client.MatchingLabels{xpv1.LabelKeyProviderName: "pc", nsv1alpha1.LabelKeyProviderConfigKind: nsv1alpha1.ProviderConfigKind},
+					client.InNamespace("team"),
+				},
+			},
+		},
+		"StaleUsage": {
+			reason: "Usages without a controller should not be counted.",
+			list:   listUsages(used, nsv1alpha1.ProviderConfigUsage{}),
+			want: want{
+				pc: func() *nsv1alpha1.ProviderConfig {
+					pc := providerConfig(false)
+					pc.SetFinalizers([]string{finalizer})
+					pc.SetUsers(1)
+					return pc
+				}(),
+			},
+		},
+		"DeletionBlocked": {
+			reason:  "Deletion of a used ProviderConfig should be blocked.",
+			deleted: true,
+			list:    listUsages(used),
+			want: want{
+				pc: func() *nsv1alpha1.ProviderConfig {
+					pc := providerConfig(true)
+					pc.SetFinalizers([]string{finalizer})
+					pc.SetUsers(1)
+					pc.SetConditions(providerconfig.Terminating().WithMessage("Blocking deletion while usages still exist"))
+					return pc
+				}(),
+			},
+		},
+		"DeletionAllowed": {
+			reason:  "The finalizer of an unused ProviderConfig being deleted should be removed.",
+			deleted: true,
+			list:    listUsages(),
+			want: want{
+				pc: func() *nsv1alpha1.ProviderConfig {
+					pc := providerConfig(true)
+					pc.SetFinalizers([]string{})
+					return pc
+				}(),
+			},
+		},
+		"ListError": {
+			reason: "Errors listing usages should be retried after a short wait.",
+			list:   test.NewMockListFn(errBoom),
+			want: want{
+				result: reconcile.Result{RequeueAfter: shortWait},
+				pc:     providerConfig(false),
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			pc := providerConfig(tc.deleted)
+			if tc.deleted {
+				pc.SetFinalizers([]string{finalizer})
+			}
+			var opts []client.ListOption
+			kube := &test.MockClient{
+				MockGet: test.NewMockGetFn(nil, func(obj client.Object) error {
+					pc.DeepCopyInto(obj.(*nsv1alpha1.ProviderConfig))
+					return nil
+				}),
+				MockList: func(ctx context.Context, l client.ObjectList, o ...client.ListOption) error {
+					opts = o
+					return tc.list(ctx, l, o...)
+				},
+				MockDelete: test.NewMockDeleteFn(nil),
+				MockUpdate: test.NewMockUpdateFn(nil, func(obj client.Object) error {
+					obj.(*nsv1alpha1.ProviderConfig).DeepCopyInto(pc)
+					return nil
+				}),
+				MockStatusUpdate: test.NewMockSubResourceUpdateFn(nil, func(obj client.Object) error {
+					obj.(*nsv1alpha1.ProviderConfig).DeepCopyInto(pc)
+					return nil
+				}),
+			}
+
+			r := &usageReconciler{
+				kube:      kube,
+				log:       logging.NewNopLogger(),
+				record:    event.NewNopRecorder(),
+				kind:      nsv1alpha1.ProviderConfigKind,
+				newConfig: func() resource.ProviderConfig { return &nsv1alpha1.ProviderConfig{} },
+			}
+			got, err := r.Reconcile(context.Background(), reconcile.Request{NamespacedName: types.NamespacedName{Namespace: "team", Name: "pc"}})
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\nr.Reconcile(...): -want error, +got error:\n%s\n", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.result, got); diff != "" {
+				t.Errorf("\n%s\nr.Reconcile(...): -want result, +got result:\n%s\n", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.pc, pc, test.EquateConditions(), cmpopts.EquateEmpty()); diff != "" {
+				t.Errorf("\n%s\nr.Reconcile(...): -want ProviderConfig, +got ProviderConfig:\n%s\n", tc.reason, diff)
+			}
+			if tc.want.opts != nil {
+				if diff := cmp.Diff(tc.want.opts, opts); diff != "" {
+					t.Errorf("\n%s\nr.Reconcile(...): -want list options, +got list options:\n%s\n", tc.reason, diff)
+				}
+			}
+		})
+	}
+}
+
+func TestUsageUsed(t *testing.T) {
+	cases := map[string]struct {
+		reason string
+		kind   string
+		pcu    *nsv1alpha1.ProviderConfigUsage
+		want   []reconcile.Request
+	}{
+		"ProviderConfig": {
+			reason: "A ProviderConfig should be enqueued in the namespace of its usage.",
+			kind:   nsv1alpha1.ProviderConfigKind,
+			pcu:    usage(nsv1alpha1.ProviderConfigKind),
+			want:   []reconcile.Request{{NamespacedName: types.NamespacedName{Namespace: "team", Name: "pc"}}},
+		},
+		"ClusterProviderConfig": {
+			reason: "A ClusterProviderConfig should be enqueued without a namespace.",
+			kind:   nsv1alpha1.ClusterProviderConfigKind,
+			pcu:    usage(nsv1alpha1.ClusterProviderConfigKind),
+			want:   []reconcile.Request{{NamespacedName: types.NamespacedName{Name: "pc"}}},
+		},
+		"OtherKind": {
+			reason: "Usages of another kind of provider config should be ignored.",
+			kind:   nsv1alpha1.ClusterProviderConfigKind,
+			pcu:    usage(nsv1alpha1.ProviderConfigKind),
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			r := &usageReconciler{kind: tc.kind}
+			got := r.used(context.Background(), tc.pcu)
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("\n%s\nr.used(...): -want, +got:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func providerConfig(deleted bool) *nsv1alpha1.ProviderConfig {
+	pc := &nsv1alpha1.ProviderConfig{ObjectMeta: metav1.ObjectMeta{Namespace: "team", Name: "pc"}}
+	if deleted {
+		deleted := metav1.NewTime(time.Unix(1, 0))
+		pc.SetDeletionTimestamp(&deleted)
+	}
+	return pc
+}
+
+func usage(kind string) *nsv1alpha1.ProviderConfigUsage {
+	return &nsv1alpha1.ProviderConfigUsage{
+		ObjectMeta:              metav1.ObjectMeta{Namespace: "team", Name: "uid"},
+		ProviderConfigReference: nsv1alpha1.ProviderConfigReference{Kind: kind, Name: "pc"},
+	}
+}
+
+func listUsages(items ...nsv1alpha1.ProviderConfigUsage) test.MockListFn {
+	return test.NewMockListFn(nil, func(l client.ObjectList) error {
+		l.(*nsv1alpha1.ProviderConfigUsageList).Items = items
+		return nil
+	})
+}
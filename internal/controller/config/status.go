@@ -0,0 +1,325 @@
+/*
+Copyright 2025 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package config
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	discoveryv1 "k8s.io/api/discovery/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	apimeta "k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/wait"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	"github.com/crossplane/crossplane-runtime/pkg/logging"
+	"github.com/crossplane/crossplane-runtime/pkg/reconciler/providerconfig"
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
+
+	nsv1alpha1 "github.com/crossplane/provider-kafkaconnect/apis/namespaced/v1alpha1"
+	"github.com/crossplane/provider-kafkaconnect/apis/v1alpha1"
+	"github.com/crossplane/provider-kafkaconnect/internal/clients/kafkaconnect"
+	"github.com/crossplane/provider-kafkaconnect/internal/options"
+)
+
+const (
+	errGetPC        = "cannot get ProviderConfig"
+	errGetCreds     = "cannot get credentials"
+	errNewClient    = "cannot create Kafka Connect client"
+	errResolve      = "cannot resolve Kafka Connect workers"
+	errUpdateStatus = "cannot update ProviderConfig status"
+)
+
+// A HealthChecker checks the health of a Kafka Connect cluster. A new one is
+// created for each check, and its idle connections are closed afterwards.
+type HealthChecker interface {
+	GetServerInfo(ctx context.Context) (*kafkaconnect.ServerInfo, error)
+	GetHealth(ctx context.Context) (*kafkaconnect.HealthStatus, error)
+	CloseIdleConnections()
+}
+
+var (
+	// Health checks are not retried; the next periodic check is the retry.
+	newHealthChecker = func(pc *v1alpha1.ProviderConfig, creds []byte, opts ...kafkaconnect.ClientOption) (HealthChecker, error) {
+		opts = append(opts, kafkaconnect.WithRetryPolicy(kafkaconnect.RetryPolicy{MaxAttempts: 1}))
+		return kafkaconnect.NewFromProviderConfig(pc, creds, opts...)
+	}
+)
+
+// SetupStatus adds controllers that periodically check the health of the
+// Kafka Connect cluster of each ProviderConfig, namespaced ProviderConfig and
+// ClusterProviderConfig and report it, along with the active worker endpoint,
+// in its status.
+func SetupStatus(mgr ctrl.Manager, o options.Options) error {
+	kinds := map[string]statusKind{
+		v1alpha1.ProviderConfigGroupKind: {
+			newConfig: func() resource.ProviderConfig { return &v1alpha1.ProviderConfig{} },
+			newList:   func() client.ObjectList { return &v1alpha1.ProviderConfigList{} },
+		},
+		nsv1alpha1.ProviderConfigKind + "." + nsv1alpha1.Group: {
+			newConfig: func() resource.ProviderConfig { return &nsv1alpha1.ProviderConfig{} },
+			newList:   func() client.ObjectList { return &nsv1alpha1.ProviderConfigList{} },
+		},
+		nsv1alpha1.ClusterProviderConfigKind + "." + nsv1alpha1.Group: {
+			newConfig: func() resource.ProviderConfig { return &nsv1alpha1.ClusterProviderConfig{} },
+			newList:   func() client.ObjectList { return &nsv1alpha1.ClusterProviderConfigList{} },
+		},
+	}
+	for gk, kind := range kinds {
+		name := "status/" + providerconfig.ControllerName(gk)
+
+		r := &statusReconciler{
+			kube:        mgr.GetClient(),
+			log:         o.Logger.WithValues("controller", name),
+			interval:    o.HealthCheckInterval,
+			newClientFn: newHealthChecker,
+			kind:        kind,
+			checks:      map[types.NamespacedName]check{},
+		}
+
+		err := ctrl.NewControllerManagedBy(mgr).
+			Named(name).
+			WithOptions(o.ForControllerRuntime()).
+			For(kind.newConfig(), builder.WithPredicates(predicate.GenerationChangedPredicate{})).
+			Watches(&corev1.Service{}, handler.EnqueueRequestsFromMapFunc(r.referencing(serviceKey))).
+			Watches(&discoveryv1.EndpointSlice{}, handler.EnqueueRequestsFromMapFunc(r.referencing(endpointSliceKey))).
+			Complete(r)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// A statusKind is a kind of provider config whose status is refreshed.
+type statusKind struct {
+	newConfig func() resource.ProviderConfig
+	newList   func() client.ObjectList
+}
+
+// referencing returns a map function that enqueues, and forces a check of,
+// the provider configs referencing the Service an object belongs to.
+func (r *statusReconciler) referencing(key func(client.Object) (types.NamespacedName, bool)) handler.MapFunc {
+	return func(ctx context.Context, obj client.Object) []reconcile.Request {
+		svc, ok := key(obj)
+		if !ok {
+			return nil
+		}
+		l := r.kind.newList()
+		if err := r.kube.List(ctx, l); err != nil {
+			r.log.Debug("Cannot list provider configs", "error", err)
+			return nil
+		}
+		pcs, err := apimeta.ExtractList(l)
+		if err != nil {
+			r.log.Debug("Cannot list provider configs", "error", err)
+			return nil
+		}
+		var reqs []reconcile.Request
+		for _, o := range pcs {
+			pc, ok := o.(resource.ProviderConfig)
+			if !ok {
+				continue
+			}
+			ref := kafkaconnect.ProviderConfigView(pc).Spec.ServiceRef
+			if ref == nil || ref.Namespace != svc.Namespace || ref.Name != svc.Name {
+				continue
+			}
+			nn := types.NamespacedName{Namespace: pc.GetNamespace(), Name: pc.GetName()}
+			r.forget(nn)
+			reqs = append(reqs, reconcile.Request{NamespacedName: nn})
+		}
+		return reqs
+	}
+}
+
+func serviceKey(obj client.Object) (types.NamespacedName, bool) {
+	return types.NamespacedName{Namespace: obj.GetNamespace(), Name: obj.GetName()}, true
+}
+
+func endpointSliceKey(obj client.Object) (types.NamespacedName, bool) {
+	name, ok := obj.GetLabels()[discoveryv1.LabelServiceName]
+	return types.NamespacedName{Namespace: obj.GetNamespace(), Name: name}, ok
+}
+
+// A statusReconciler periodically refreshes the status of the provider
+// configs of one kind.
+type statusReconciler struct {
+	kube        client.Client
+	log         logging.Logger
+	interval    time.Duration
+	newClientFn func(pc *v1alpha1.ProviderConfig, creds []byte, opts ...kafkaconnect.ClientOption) (HealthChecker, error)
+	kind        statusKind
+
+	// checks records when each provider config was last checked, so that it
+	// is checked at most once per interval unless its spec changes.
+	mu     sync.Mutex
+	checks map[types.NamespacedName]check
+}
+
+type check struct {
+	time       time.Time
+	generation int64
+}
+
+// Reconcile the status of a provider config. Namespaced ProviderConfigs and
+// ClusterProviderConfigs are checked through the view their Connectors use,
+// so that the status reports the state kept for them.
+func (r *statusReconciler) Reconcile(ctx context.Context, req reconcile.Request) (reconcile.Result, error) {
+	cfg := r.kind.newConfig()
+	if err := r.kube.Get(ctx, req.NamespacedName, cfg); err != nil {
+		r.forget(req.NamespacedName)
+		if kerrors.IsNotFound(err) {
+			// Drop the state kept for the deleted provider config, which
+			// is named like its view.
+			cfg.SetNamespace(req.Namespace)
+			cfg.SetName(req.Name)
+			kafkaconnect.Forget(kafkaconnect.ProviderConfigView(cfg).GetName())
+		}
+		return reconcile.Result{}, errors.Wrap(resource.IgnoreNotFound(err), errGetPC)
+	}
+
+	pc := kafkaconnect.ProviderConfigView(cfg)
+	orig := pc.Status.DeepCopy()
+	if r.due(req.NamespacedName, pc.GetGeneration()) {
+		r.check(ctx, cfg, pc)
+	}
+	if active := kafkaconnect.ActiveEndpoint(pc.GetName()); active != "" {
+		pc.Status.ActiveEndpoint = active
+	}
+	pc.Status.CircuitBreaker = string(kafkaconnect.BreakerStateOf(pc.GetName()))
+
+	requeue := reconcile.Result{RequeueAfter: wait.Jitter(r.interval, 0.1)}
+	if statusEqual(orig, &pc.Status) {
+		return requeue, nil
+	}
+	setStatus(cfg, pc.Status)
+	return requeue, errors.Wrap(r.kube.Status().Update(ctx, cfg), errUpdateStatus)
+}
+
+// setStatus copies the supplied status to a provider config.
+func setStatus(pc resource.ProviderConfig, s v1alpha1.ProviderConfigStatus) {
+	switch pc := pc.(type) {
+	case *v1alpha1.ProviderConfig:
+		pc.Status = s
+	case *nsv1alpha1.ProviderConfig:
+		pc.Status = s
+	case *nsv1alpha1.ClusterProviderConfig:
+		pc.Status = s
+	}
+}
+
+// check the health of the Kafka Connect cluster of the supplied provider
+// config and record it in the status of its view.
+func (r *statusReconciler) check(ctx context.Context, cfg resource.ProviderConfig, pc *v1alpha1.ProviderConfig) {
+	info, err := r.serverInfo(ctx, cfg, pc)
+	if err != nil {
+		r.log.Debug("Kafka Connect health check failed", "providerconfig", pc.GetName(), "error", err)
+		pc.SetConditions(v1alpha1.Unhealthy(err))
+		return
+	}
+
+	pc.Status.Version = info.Version
+	pc.Status.Commit = info.Commit
+	pc.Status.KafkaClusterID = info.KafkaClusterID
+
+	id := kafkaconnect.ClusterIdentity{KafkaClusterID: pc.Spec.ExpectedKafkaClusterID, ConnectGroup: pc.Spec.ExpectedConnectGroup}
+	if err := id.Verify(info); err != nil {
+		pc.SetConditions(v1alpha1.ClusterMismatch(err))
+		return
+	}
+	pc.SetConditions(v1alpha1.Healthy())
+}
+
+func (r *statusReconciler) serverInfo(ctx context.Context, cfg resource.ProviderConfig, pc *v1alpha1.ProviderConfig) (*kafkaconnect.ServerInfo, error) {
+	// A namespaced ProviderConfig may only use Secrets and Services in its
+	// namespace, which the view no longer records.
+	if nspc, ok := cfg.(*nsv1alpha1.ProviderConfig); ok {
+		if err := kafkaconnect.CheckNamespace(nspc); err != nil {
+			return nil, err
+		}
+	}
+
+	cd := pc.Spec.Credentials
+	data, err := resource.CommonCredentialExtractor(ctx, cd.Source, r.kube, cd.CommonCredentialSelectors)
+	if err != nil {
+		return nil, errors.Wrap(err, errGetCreds)
+	}
+
+	urls, err := kafkaconnect.ResolveWorkers(ctx, r.kube, pc)
+	if err != nil {
+		return nil, errors.Wrap(err, errResolve)
+	}
+
+	hc, err := r.newClientFn(pc, data, kafkaconnect.WithWorkers(urls...))
+	if err != nil {
+		return nil, errors.Wrap(err, errNewClient)
+	}
+	// The client is not reused, so its connections would otherwise be kept
+	// open until they time out.
+	defer hc.CloseIdleConnections()
+
+	info, err := hc.GetServerInfo(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	// Workers that predate the health endpoint answer 404, unless their
+	// version tells the client not to ask.
+	if _, err := hc.GetHealth(ctx); err != nil && !kafkaconnect.IsNotFound(err) && !kafkaconnect.IsUnsupported(err) {
+		return nil, err
+	}
+	return info, nil
+}
+
+// due returns true if the supplied provider config should be checked, i.e. if
+// it was not checked within the last interval or its spec changed since.
+func (r *statusReconciler) due(nn types.NamespacedName, generation int64) bool {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	last, ok := r.checks[nn]
+	if ok && last.generation == generation && time.Since(last.time) < r.interval/2 {
+		return false
+	}
+	r.checks[nn] = check{time: time.Now(), generation: generation}
+	return true
+}
+
+func (r *statusReconciler) forget(nn types.NamespacedName) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	delete(r.checks, nn)
+}
+
+// statusEqual returns true if the supplied statuses are equal, ignoring the
+// transition time of conditions.
+func statusEqual(a, b *v1alpha1.ProviderConfigStatus) bool {
+	if a.ActiveEndpoint != b.ActiveEndpoint || a.Version != b.Version || a.Commit != b.Commit || a.KafkaClusterID != b.KafkaClusterID || a.CircuitBreaker != b.CircuitBreaker || a.Users != b.Users {
+		return false
+	}
+	return a.ConditionedStatus.Equal(&b.ConditionedStatus)
+}
@@ -0,0 +1,316 @@
+/*
+Copyright 2025 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package config
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	"github.com/pkg/errors"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/logging"
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+
+	nsv1alpha1 "github.com/crossplane/provider-kafkaconnect/apis/namespaced/v1alpha1"
+	"github.com/crossplane/provider-kafkaconnect/apis/v1alpha1"
+	"github.com/crossplane/provider-kafkaconnect/internal/clients/kafkaconnect"
+)
+
+type fakeHealthChecker struct {
+	info      *kafkaconnect.ServerInfo
+	infoErr   error
+	healthErr error
+	calls     int
+	closed    bool
+}
+
+func (f *fakeHealthChecker) GetServerInfo(_ context.Context) (*kafkaconnect.ServerInfo, error) {
+	f.calls++
+	return f.info, f.infoErr
+}
+
+func (f *fakeHealthChecker) GetHealth(_ context.Context) (*kafkaconnect.HealthStatus, error) {
+	return &kafkaconnect.HealthStatus{Status: "healthy"}, f.healthErr
+}
+
+func (f *fakeHealthChecker) CloseIdleConnections() {
+	f.closed = true
+}
+
+func TestStatusReconcile(t *testing.T) {
+	errBoom := errors.New("boom")
+	info := &kafkaconnect.ServerInfo{Version: "3.9.0", Commit: "abc", KafkaClusterID: "cluster"}
+
+	cases := map[string]struct {
+		reason string
+		spec   v1alpha1.ProviderConfigSpec
+		hc     *fakeHealthChecker
+		want   *v1alpha1.ProviderConfigStatus
+	}{
+		"Healthy": {
+			reason: "A successful health check should record the worker's version and cluster ID.",
+			hc:     &fakeHealthChecker{info: info},
+			want: func() *v1alpha1.ProviderConfigStatus {
+				s := &v1alpha1.ProviderConfigStatus{Version: "3.9.0", Commit: "abc", KafkaClusterID: "cluster", CircuitBreaker: "Closed"}
+				s.SetConditions(v1alpha1.Healthy())
+				return s
+			}(),
+		},
+		"HealthEndpointNotSupported": {
+			reason: "Workers that do not support the health endpoint should be considered healthy.",
+			hc:     &fakeHealthChecker{info: info, healthErr: &kafkaconnect.APIError{StatusCode: http.StatusNotFound}},
+			want: func() *v1alpha1.ProviderConfigStatus {
+				s := &v1alpha1.ProviderConfigStatus{Version: "3.9.0", Commit: "abc", KafkaClusterID: "cluster", CircuitBreaker: "Closed"}
+				s.SetConditions(v1alpha1.Healthy())
+				return s
+			}(),
+		},
+		"ClusterMismatch": {
+			reason: "A worker connected to an unexpected Kafka cluster should set a ClusterMismatch condition.",
+			spec:   v1alpha1.ProviderConfigSpec{ExpectedKafkaClusterID: "other"},
+			hc:     &fakeHealthChecker{info: info},
+			want: func() *v1alpha1.ProviderConfigStatus {
+				s := &v1alpha1.ProviderConfigStatus{Version: "3.9.0", Commit: "abc", KafkaClusterID: "cluster", CircuitBreaker: "Closed"}
+				s.SetConditions(v1alpha1.ClusterMismatch(&kafkaconnect.ClusterMismatchError{Field: "Kafka cluster ID", Expected: "other", Actual: "cluster"}))
+				return s
+			}(),
+		},
+		"Unhealthy": {
+			reason: "A failed health check should set an Unhealthy condition with the error.",
+			hc:     &fakeHealthChecker{infoErr: errBoom},
+			want: func() *v1alpha1.ProviderConfigStatus {
+				s := &v1alpha1.ProviderConfigStatus{CircuitBreaker: "Closed"}
+				s.SetConditions(v1alpha1.Unhealthy(errBoom))
+				return s
+			}(),
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			var got *v1alpha1.ProviderConfigStatus
+			kube := &test.MockClient{
+				MockGet: test.NewMockGetFn(nil, func(obj client.Object) error {
+					pc := obj.(*v1alpha1.ProviderConfig)
+					pc.SetName("pc")
+					pc.Spec = tc.spec
+					pc.Spec.Credentials.Source = xpv1.CredentialsSourceNone
+					return nil
+				}),
+				MockStatusUpdate: func(_ context.Context, obj client.Object, _ ...client.SubResourceUpdateOption) error {
+					got = obj.(*v1alpha1.ProviderConfig).Status.DeepCopy()
+					return nil
+				},
+			}
+			r := &statusReconciler{
+				kube:     kube,
+				log:      logging.NewNopLogger(),
+				interval: time.Minute,
+				newClientFn: func(_ *v1alpha1.ProviderConfig, _ []byte, _ ...kafkaconnect.ClientOption) (HealthChecker, error) {
+					return tc.hc, nil
+				},
+				kind:   statusKind{newConfig: func() resource.ProviderConfig { return &v1alpha1.ProviderConfig{} }},
+				checks: map[types.NamespacedName]check{},
+			}
+
+			if _, err := r.Reconcile(context.Background(), reconcile.Request{}); err != nil {
+				t.Fatalf("\n%s\nr.Reconcile(...): %v", tc.reason, err)
+			}
+			if diff := cmp.Diff(tc.want, got, cmpopts.IgnoreTypes(metav1.Time{})); diff != "" {
+				t.Errorf("\n%s\nr.Reconcile(...): -want status, +got status:\n%s", tc.reason, diff)
+			}
+			if !tc.hc.closed {
+				t.Errorf("\n%s\nr.Reconcile(...): want the idle connections of the health checker closed", tc.reason)
+			}
+
+			// A second reconcile within the interval must not check again.
+			if _, err := r.Reconcile(context.Background(), reconcile.Request{}); err != nil {
+				t.Fatalf("\n%s\nr.Reconcile(...): %v", tc.reason, err)
+			}
+			if tc.hc.calls != 1 {
+				t.Errorf("\n%s\nr.Reconcile(...): want no health check within the interval, got %d checks", tc.reason, tc.hc.calls)
+			}
+		})
+	}
+}
+
+func TestStatusReconcileNamespaced(t *testing.T) {
+	info := &kafkaconnect.ServerInfo{Version: "3.9.0"}
+
+	cases := map[string]struct {
+		reason    string
+		kind      statusKind
+		req       reconcile.Request
+		view      string
+		getStatus func(client.Object) *v1alpha1.ProviderConfigStatus
+	}{
+		"ProviderConfig": {
+			reason: "The status of a namespaced ProviderConfig should report the state kept for the view its Connectors use.",
+			kind:   statusKind{newConfig: func() resource.ProviderConfig { return &nsv1alpha1.ProviderConfig{} }},
+			req:    reconcile.Request{NamespacedName: types.NamespacedName{Namespace: "team-a", Name: "status"}},
+			view:   "team-a/status",
+			getStatus: func(obj client.Object) *v1alpha1.ProviderConfigStatus {
+				return obj.(*nsv1alpha1.ProviderConfig).Status.DeepCopy()
+			},
+		},
+		"ClusterProviderConfig": {
+			reason: "The status of a ClusterProviderConfig should report the state kept for the view its Connectors use.",
+			kind:   statusKind{newConfig: func() resource.ProviderConfig { return &nsv1alpha1.ClusterProviderConfig{} }},
+			req:    reconcile.Request{NamespacedName: types.NamespacedName{Name: "status"}},
+			view:   nsv1alpha1.ClusterProviderConfigKind + "/status",
+			getStatus: func(obj client.Object) *v1alpha1.ProviderConfigStatus {
+				return obj.(*nsv1alpha1.ClusterProviderConfig).Status.DeepCopy()
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			endpoint := "http://" + strings.ToLower(name) + ":8083"
+			kafkaconnect.EndpointsFor(tc.view, []string{endpoint})
+
+			var got *v1alpha1.ProviderConfigStatus
+			kube := &test.MockClient{
+				MockGet: test.NewMockGetFn(nil, func(obj client.Object) error {
+					switch pc := obj.(type) {
+					case *nsv1alpha1.ProviderConfig:
+						pc.Spec.Credentials.Source = xpv1.CredentialsSourceNone
+					case *nsv1alpha1.ClusterProviderConfig:
+						pc.Spec.Credentials.Source = xpv1.CredentialsSourceNone
+					}
+					obj.SetNamespace(tc.req.Namespace)
+					obj.SetName(tc.req.Name)
+					return nil
+				}),
+				MockStatusUpdate: func(_ context.Context, obj client.Object, _ ...client.SubResourceUpdateOption) error {
+					got = tc.getStatus(obj)
+					return nil
+				},
+			}
+			var checked string
+			r := &statusReconciler{
+				kube:     kube,
+				log:      logging.NewNopLogger(),
+				interval: time.Minute,
+				newClientFn: func(pc *v1alpha1.ProviderConfig, _ []byte, _ ...kafkaconnect.ClientOption) (HealthChecker, error) {
+					checked = pc.GetName()
+					return &fakeHealthChecker{info: info}, nil
+				},
+				kind:   tc.kind,
+				checks: map[types.NamespacedName]check{},
+			}
+
+			if _, err := r.Reconcile(context.Background(), tc.req); err != nil {
+				t.Fatalf("\n%s\nr.Reconcile(...): %v", tc.reason, err)
+			}
+			if diff := cmp.Diff(tc.view, checked); diff != "" {
+				t.Errorf("\n%s\nr.Reconcile(...): -want checked view, +got:\n%s", tc.reason, diff)
+			}
+			want := &v1alpha1.ProviderConfigStatus{Version: "3.9.0", ActiveEndpoint: endpoint, CircuitBreaker: "Closed"}
+			want.SetConditions(v1alpha1.Healthy())
+			if diff := cmp.Diff(want, got, cmpopts.IgnoreTypes(metav1.Time{})); diff != "" {
+				t.Errorf("\n%s\nr.Reconcile(...): -want status, +got status:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestStatusReconcileDeleted(t *testing.T) {
+	kafkaconnect.EndpointsFor("team-a/deleted", []string{"http://deleted:8083"})
+
+	r := &statusReconciler{
+		kube:   &test.MockClient{MockGet: test.NewMockGetFn(kerrors.NewNotFound(schema.GroupResource{}, "deleted"))},
+		log:    logging.NewNopLogger(),
+		kind:   statusKind{newConfig: func() resource.ProviderConfig { return &nsv1alpha1.ProviderConfig{} }},
+		checks: map[types.NamespacedName]check{},
+	}
+	req := reconcile.Request{NamespacedName: types.NamespacedName{Namespace: "team-a", Name: "deleted"}}
+	if _, err := r.Reconcile(context.Background(), req); err != nil {
+		t.Fatalf("r.Reconcile(...): %v", err)
+	}
+
+	// The state kept for the view of a deleted provider config must be
+	// dropped, rather than kept for the lifetime of the provider.
+	if got := kafkaconnect.ActiveEndpoint("team-a/deleted"); got != "" {
+		t.Errorf("kafkaconnect.ActiveEndpoint(...): want no endpoint set for a deleted ProviderConfig, got active endpoint %q", got)
+	}
+}
+
+func TestStatusReconcileNamespaceCheck(t *testing.T) {
+	req := reconcile.Request{NamespacedName: types.NamespacedName{Namespace: "team-a", Name: "foreign"}}
+
+	var got *v1alpha1.ProviderConfigStatus
+	kube := &test.MockClient{
+		MockGet: func(_ context.Context, _ client.ObjectKey, obj client.Object) error {
+			pc, ok := obj.(*nsv1alpha1.ProviderConfig)
+			if !ok {
+				t.Errorf("r.Reconcile(...): unexpected get of %T", obj)
+				return errors.New("unexpected get")
+			}
+			pc.SetNamespace(req.Namespace)
+			pc.SetName(req.Name)
+			pc.Spec.KafkaConnectURL = "http://connect:8083"
+			pc.Spec.Credentials = v1alpha1.ProviderCredentials{
+				Source: xpv1.CredentialsSourceSecret,
+				CommonCredentialSelectors: xpv1.CommonCredentialSelectors{SecretRef: &xpv1.SecretKeySelector{
+					SecretReference: xpv1.SecretReference{Namespace: "crossplane-system", Name: "creds"},
+					Key:             "credentials",
+				}},
+			}
+			return nil
+		},
+		MockStatusUpdate: func(_ context.Context, obj client.Object, _ ...client.SubResourceUpdateOption) error {
+			got = obj.(*nsv1alpha1.ProviderConfig).Status.DeepCopy()
+			return nil
+		},
+	}
+	r := &statusReconciler{
+		kube:     kube,
+		log:      logging.NewNopLogger(),
+		interval: time.Minute,
+		newClientFn: func(_ *v1alpha1.ProviderConfig, _ []byte, _ ...kafkaconnect.ClientOption) (HealthChecker, error) {
+			t.Errorf("r.Reconcile(...): unexpected health check of a ProviderConfig using a Secret in another namespace")
+			return &fakeHealthChecker{}, nil
+		},
+		kind:   statusKind{newConfig: func() resource.ProviderConfig { return &nsv1alpha1.ProviderConfig{} }},
+		checks: map[types.NamespacedName]check{},
+	}
+
+	// A namespaced ProviderConfig must not be able to read the credentials of
+	// another namespace through its health check.
+	if _, err := r.Reconcile(context.Background(), req); err != nil {
+		t.Fatalf("r.Reconcile(...): %v", err)
+	}
+	want := &v1alpha1.ProviderConfigStatus{CircuitBreaker: "Closed"}
+	want.SetConditions(v1alpha1.Unhealthy(errors.New("ProviderConfig team-a/foreign cannot use credentials from Secret crossplane-system/creds in another namespace")))
+	if diff := cmp.Diff(want, got, cmpopts.IgnoreTypes(metav1.Time{})); diff != "" {
+		t.Errorf("r.Reconcile(...): -want status, +got status:\n%s", diff)
+	}
+}
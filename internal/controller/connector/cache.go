@@ -0,0 +1,133 @@
+/*
+Copyright 2025 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package connector
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"sync"
+	"time"
+
+	"k8s.io/apimachinery/pkg/types"
+
+	apisv1alpha1 "github.com/crossplane/provider-kafkaconnect/apis/v1alpha1"
+)
+
+// Services that are not in use are evicted from the cache after this long.
+const serviceIdleTTL = 10 * time.Minute
+
+// A serviceKey identifies the services of a ProviderConfig. Connectors that
+// override the ProviderConfig's URL get a service per URL.
+type serviceKey struct {
+	uid types.UID
+	url string
+}
+
+// A serviceCache caches the services of each ProviderConfig, so that their
+// connections to Kafka Connect are reused across reconciles. A service is
+// replaced when the ProviderConfig's spec, its credentials or its resolved
+// workers change.
+type serviceCache struct {
+	mu      sync.Mutex
+	entries map[serviceKey]*cachedService
+	now     func() time.Time
+}
+
+type cachedService struct {
+	hash     string
+	service  Service
+	refs     int
+	lastUsed time.Time
+}
+
+func newServiceCache() *serviceCache {
+	return &serviceCache{entries: map[serviceKey]*cachedService{}, now: time.Now}
+}
+
+// Get returns the cached service for the supplied key if it was created with
+// the supplied hash, or else a new service. The returned function releases
+// the reference to the service.
+func (c *serviceCache) Get(key serviceKey, hash string, newFn func() (Service, error)) (Service, func(), error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	now := c.now()
+	c.evict(now)
+
+	e, ok := c.entries[key]
+	if !ok || e.hash != hash {
+		svc, err := newFn()
+		if err != nil {
+			return nil, nil, err
+		}
+		if ok {
+			closeIdle(e.service)
+		}
+		e = &cachedService{hash: hash, service: svc}
+		c.entries[key] = e
+	}
+	e.refs++
+	e.lastUsed = now
+
+	var once sync.Once
+	release := func() {
+		once.Do(func() {
+			c.mu.Lock()
+			defer c.mu.Unlock()
+			e.refs--
+			e.lastUsed = c.now()
+		})
+	}
+	return e.service, release, nil
+}
+
+// evict services that were not used for the idle TTL, e.g. those of deleted
+// ProviderConfigs.
+func (c *serviceCache) evict(now time.Time) {
+	for k, e := range c.entries {
+		if e.refs <= 0 && now.Sub(e.lastUsed) > serviceIdleTTL {
+			closeIdle(e.service)
+			delete(c.entries, k)
+		}
+	}
+}
+
+// closeIdle closes the idle connections of the supplied service, if it keeps
+// any.
+func closeIdle(svc Service) {
+	if c, ok := svc.(interface{ CloseIdleConnections() }); ok {
+		c.CloseIdleConnections()
+	}
+}
+
+// serviceHash returns a hash of everything a service of the supplied
+// ProviderConfig is created from.
+func serviceHash(pc *apisv1alpha1.ProviderConfig, creds []byte, workers []string) string {
+	h := sha256.New()
+	// Encoding a spec of plain fields cannot fail.
+	spec, _ := json.Marshal(pc.Spec) //nolint:errchkjson // See above.
+	for _, b := range [][]byte{spec, creds} {
+		_, _ = h.Write(b)
+		_, _ = h.Write([]byte{0})
+	}
+	for _, w := range workers {
+		_, _ = h.Write([]byte(w))
+		_, _ = h.Write([]byte{0})
+	}
+	return hex.EncodeToString(h.Sum(nil))
+}
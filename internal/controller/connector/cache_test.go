@@ -0,0 +1,90 @@
+/*
+Copyright 2025 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package connector
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/crossplane/provider-kafkaconnect/internal/clients/kafkaconnect"
+)
+
+func TestServiceCacheGet(t *testing.T) {
+	type get struct {
+		hash    string
+		release bool
+		after   time.Duration
+	}
+
+	cases := map[string]struct {
+		reason string
+		gets   []get
+		want   int
+	}{
+		"Reused": {
+			reason: "A service should be reused while the hash is unchanged.",
+			gets:   []get{{hash: "a", release: true}, {hash: "a"}},
+			want:   1,
+		},
+		"Changed": {
+			reason: "A service should be replaced when the hash changes.",
+			gets:   []get{{hash: "a", release: true}, {hash: "b"}},
+			want:   2,
+		},
+		"Idle": {
+			reason: "A released service should be evicted once it is idle for the TTL.",
+			gets:   []get{{hash: "a", release: true}, {hash: "a", after: 2 * serviceIdleTTL}},
+			want:   2,
+		},
+		"InUse": {
+			reason: "A service in use should not be evicted.",
+			gets:   []get{{hash: "a"}, {hash: "a", after: 2 * serviceIdleTTL}},
+			want:   1,
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			now := time.Now()
+			c := newServiceCache()
+			c.now = func() time.Time { return now }
+
+			created := 0
+			newFn := func() (Service, error) {
+				created++
+				return kafkaconnect.NewClient("http://connect"), nil
+			}
+
+			for _, g := range tc.gets {
+				now = now.Add(g.after)
+				_, release, err := c.Get(serviceKey{uid: "pc"}, g.hash, newFn)
+				if err != nil {
+					t.Fatalf("\n%s\nc.Get(...): %v", tc.reason, err)
+				}
+				if g.release {
+					release()
+					release()
+				}
+			}
+			if diff := cmp.Diff(tc.want, created); diff != "" {
+				t.Errorf("\n%s\nc.Get(...): -want services created, +got:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,476 @@
+/*
+Copyright 2025 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package connector
+
+import (
+	"bytes"
+	"encoding/json"
+	"maps"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/pkg/errors"
+	extv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+
+	"github.com/crossplane/provider-kafkaconnect/apis/kafkaconnect/v1alpha1"
+)
+
+const (
+	errRenderConfig   = "cannot render connector config"
+	errFmtConflict    = "config key %q conflicts with %s"
+	errFmtReservedKey = "%s %q may not set config key %q"
+	errFmtNoPredicate = "transform %q references unknown predicate %q"
+	errFmtDuplicate   = "%s %q is configured more than once"
+	errFmtNegative    = "%s may not be negative"
+	errDLQTolerance   = "a dead letter queue requires tolerance all"
+	errFmtUnresolved  = "secret key %s/%s/%s is not resolved"
+	errFmtConfigValue = "invalid value of config key %q"
+	errValueType      = "value must be a string, number, boolean, or list of them"
+	errListComma      = "list elements may not contain commas"
+)
+
+// Kafka Connect connector configuration keys rendered from typed
+// ConnectorParameters fields.
+const (
+	keyTransforms = "transforms"
+	keyPredicates = "predicates"
+	keyErrors     = "errors"
+
+	keyTopicCreation       = "topic.creation"
+	keyTopicCreationGroups = "topic.creation.groups"
+
+	keyErrorsTolerance      = "errors.tolerance"
+	keyErrorsRetryTimeout   = "errors.retry.timeout"
+	keyErrorsRetryDelayMax  = "errors.retry.delay.max.ms"
+	keyErrorsLogEnable      = "errors.log.enable"
+	keyErrorsLogMessages    = "errors.log.include.messages"
+	keyDLQTopicName         = "errors.deadletterqueue.topic.name"
+	keyDLQReplicationFactor = "errors.deadletterqueue.topic.replication.factor"
+	keyDLQContextHeaders    = "errors.deadletterqueue.context.headers.enable"
+)
+
+// Converter roles, i.e. the parts of records a converter converts.
+const (
+	roleKey    = "key"
+	roleValue  = "value"
+	roleHeader = "header"
+)
+
+// Converter configuration keys, relative to the converter's prefix.
+const (
+	keyConverterAuthSource   = "basic.auth.credentials.source"
+	keyConverterAuthUserInfo = "basic.auth.user.info"
+
+	authSourceUserInfo = "USER_INFO"
+)
+
+// Topic creation configuration keys, relative to the prefix of a group.
+const (
+	keyGroupReplicationFactor = "replication.factor"
+	keyGroupPartitions        = "partitions"
+	keyGroupInclude           = "include"
+	keyGroupExclude           = "exclude"
+
+	groupDefault = "default"
+)
+
+// Error tolerances.
+const (
+	toleranceNone = "none"
+	toleranceAll  = "all"
+)
+
+// A block of typed ConnectorParameters fields that is rendered into
+// connector configuration keys.
+type block struct {
+	// field of the ConnectorParameters the block is configured by.
+	field string
+
+	// namespaces of the configuration keys owned by the block. A key is in a
+	// namespace if it equals it, or starts with it followed by a dot. Config
+	// may not set keys in the namespaces of a configured block.
+	namespaces []string
+
+	// render the block. It returns no keys if the block is not configured.
+	render func(p v1alpha1.ConnectorParameters, secrets secretValues) (map[string]string, error)
+}
+
+// secretValues are the values of the Secret keys referenced by typed fields.
+type secretValues map[xpv1.SecretKeySelector]string
+
+// blocks rendered into the connector configuration, in order.
+var blocks = []block{
+	{field: "transforms", namespaces: []string{keyTransforms}, render: renderTransforms},
+	{field: "predicates", namespaces: []string{keyPredicates}, render: renderPredicates},
+	{field: "errorHandling", namespaces: []string{keyErrors}, render: renderErrorHandling},
+	{field: "converters.key", namespaces: []string{converterPrefix(roleKey)}, render: renderConverter(roleKey)},
+	{field: "converters.value", namespaces: []string{converterPrefix(roleValue)}, render: renderConverter(roleValue)},
+	{field: "converters.header", namespaces: []string{converterPrefix(roleHeader)}, render: renderConverter(roleHeader)},
+	{field: "topicCreation", namespaces: []string{keyTopicCreation}, render: renderTopicCreation},
+}
+
+// fieldKeys are the configuration keys set by their own ConnectorParameters
+// fields, which Config may never set.
+var fieldKeys = map[string]string{keyName: "name", keyConnectorClass: "connectorClass", keyTasksMax: "tasksMax"}
+
+// desiredConfig returns the connector configuration as Kafka Connect reports
+// it, i.e. including the connector's name, class and maximum number of tasks,
+// and the keys rendered from typed fields. Secret keys referenced by typed
+// fields must be resolved in the supplied values. It returns an error if
+// Config sets keys owned by a field, or by a configured typed field.
+func desiredConfig(cr *v1alpha1.Connector, secrets secretValues) (map[string]string, error) {
+	p := cr.Spec.ForProvider
+	cfg := make(map[string]string, len(p.Config)+3)
+	for _, k := range slices.Sorted(maps.Keys(p.Config)) {
+		if field, ok := fieldKeys[k]; ok {
+			return nil, errors.Wrap(errors.Errorf(errFmtConflict, k, field), errRenderConfig)
+		}
+		v, err := configValue(p.Config[k])
+		if err != nil {
+			return nil, errors.Wrap(errors.Wrapf(err, errFmtConfigValue, k), errRenderConfig)
+		}
+		cfg[k] = v
+	}
+
+	for _, b := range blocks {
+		rendered, err := b.render(p, secrets)
+		if err != nil {
+			return nil, errors.Wrap(err, errRenderConfig)
+		}
+		if len(rendered) == 0 {
+			continue
+		}
+		for _, k := range slices.Sorted(maps.Keys(p.Config)) {
+			if inNamespace(k, b.namespaces...) {
+				return nil, errors.Wrap(errors.Errorf(errFmtConflict, k, b.field), errRenderConfig)
+			}
+		}
+		maps.Copy(cfg, rendered)
+	}
+
+	cfg[keyName] = connectorName(cr)
+	cfg[keyConnectorClass] = p.ConnectorClass
+	if p.TasksMax > 0 {
+		cfg[keyTasksMax] = strconv.Itoa(p.TasksMax)
+	}
+	return cfg, nil
+}
+
+// configValue returns the supplied config value in the string form Kafka
+// Connect expects. Strings are returned as is, numbers in plain decimal
+// notation, booleans as true or false, and lists as their elements joined
+// with commas.
+func configValue(v extv1.JSON) (string, error) {
+	d := json.NewDecoder(bytes.NewReader(v.Raw))
+	d.UseNumber()
+	var val any
+	if err := d.Decode(&val); err != nil {
+		return "", err
+	}
+
+	l, ok := val.([]any)
+	if !ok {
+		return scalarValue(val)
+	}
+	elems := make([]string, 0, len(l))
+	for _, e := range l {
+		s, err := scalarValue(e)
+		if err != nil {
+			return "", err
+		}
+		if strings.Contains(s, ",") {
+			return "", errors.New(errListComma)
+		}
+		elems = append(elems, s)
+	}
+	return strings.Join(elems, ","), nil
+}
+
+// scalarValue returns the supplied string, number or boolean as a string.
+// Integers are returned as written, since they may not fit in an int64.
+func scalarValue(v any) (string, error) {
+	switch v := v.(type) {
+	case string:
+		return v, nil
+	case bool:
+		return strconv.FormatBool(v), nil
+	case json.Number:
+		if !strings.ContainsAny(v.String(), ".eE") {
+			return v.String(), nil
+		}
+		f, err := v.Float64()
+		if err != nil {
+			return "", err
+		}
+		return strconv.FormatFloat(f, 'f', -1, 64), nil
+	}
+	return "", errors.New(errValueType)
+}
+
+// inNamespace returns true if the supplied key is in any of the supplied
+// namespaces.
+func inNamespace(key string, namespaces ...string) bool {
+	for _, ns := range namespaces {
+		if key == ns || strings.HasPrefix(key, ns+".") {
+			return true
+		}
+	}
+	return false
+}
+
+// renderTransforms renders transforms into the transforms key listing their
+// aliases in order, and the transforms.<alias>.* keys configuring each.
+func renderTransforms(p v1alpha1.ConnectorParameters, _ secretValues) (map[string]string, error) {
+	if len(p.Transforms) == 0 {
+		return nil, nil
+	}
+	predicates := make(map[string]bool, len(p.Predicates))
+	for _, pr := range p.Predicates {
+		predicates[pr.Name] = true
+	}
+
+	cfg := map[string]string{}
+	names := make([]string, 0, len(p.Transforms))
+	for _, t := range p.Transforms {
+		if slices.Contains(names, t.Name) {
+			return nil, errors.Errorf(errFmtDuplicate, "transform", t.Name)
+		}
+		names = append(names, t.Name)
+
+		prefix := keyTransforms + "." + t.Name + "."
+		for k, v := range t.Config {
+			if k == "type" || k == "predicate" || k == "negate" {
+				return nil, errors.Errorf(errFmtReservedKey, "transform", t.Name, k)
+			}
+			cfg[prefix+k] = v
+		}
+		cfg[prefix+"type"] = t.Type
+		if t.Predicate != "" {
+			if !predicates[t.Predicate] {
+				return nil, errors.Errorf(errFmtNoPredicate, t.Name, t.Predicate)
+			}
+			cfg[prefix+"predicate"] = t.Predicate
+		}
+		if t.Negate {
+			cfg[prefix+"negate"] = "true"
+		}
+	}
+	cfg[keyTransforms] = strings.Join(names, ",")
+	return cfg, nil
+}
+
+// renderPredicates renders predicates into the predicates key listing their
+// aliases in order, and the predicates.<alias>.* keys configuring each.
+func renderPredicates(p v1alpha1.ConnectorParameters, _ secretValues) (map[string]string, error) {
+	if len(p.Predicates) == 0 {
+		return nil, nil
+	}
+
+	cfg := map[string]string{}
+	names := make([]string, 0, len(p.Predicates))
+	for _, pr := range p.Predicates {
+		if slices.Contains(names, pr.Name) {
+			return nil, errors.Errorf(errFmtDuplicate, "predicate", pr.Name)
+		}
+		names = append(names, pr.Name)
+
+		prefix := keyPredicates + "." + pr.Name + "."
+		for k, v := range pr.Config {
+			if k == "type" {
+				return nil, errors.Errorf(errFmtReservedKey, "predicate", pr.Name, k)
+			}
+			cfg[prefix+k] = v
+		}
+		cfg[prefix+"type"] = pr.Type
+	}
+	cfg[keyPredicates] = strings.Join(names, ",")
+	return cfg, nil
+}
+
+// renderErrorHandling renders error handling into the errors.* keys.
+func renderErrorHandling(p v1alpha1.ConnectorParameters, _ secretValues) (map[string]string, error) {
+	eh := p.ErrorHandling
+	if eh == nil {
+		return nil, nil
+	}
+
+	tolerance := eh.Tolerance
+	if tolerance == "" {
+		tolerance = toleranceNone
+	}
+	cfg := map[string]string{keyErrorsTolerance: tolerance}
+
+	if eh.RetryTimeout != nil {
+		// Kafka Connect retries forever if the timeout is -1.
+		ms := max(eh.RetryTimeout.Milliseconds(), -1)
+		cfg[keyErrorsRetryTimeout] = strconv.FormatInt(ms, 10)
+	}
+	if eh.RetryDelayMax != nil {
+		if eh.RetryDelayMax.Duration < 0 {
+			return nil, errors.Errorf(errFmtNegative, "retryDelayMax")
+		}
+		cfg[keyErrorsRetryDelayMax] = strconv.FormatInt(eh.RetryDelayMax.Milliseconds(), 10)
+	}
+
+	if l := eh.Log; l != nil {
+		cfg[keyErrorsLogEnable] = strconv.FormatBool(l.Enable)
+		cfg[keyErrorsLogMessages] = strconv.FormatBool(l.IncludeMessages)
+	}
+
+	if dlq := eh.DeadLetterQueue; dlq != nil {
+		if tolerance != toleranceAll {
+			return nil, errors.New(errDLQTolerance)
+		}
+		cfg[keyDLQTopicName] = dlq.TopicName
+		if dlq.ReplicationFactor != nil {
+			cfg[keyDLQReplicationFactor] = strconv.Itoa(*dlq.ReplicationFactor)
+		}
+		cfg[keyDLQContextHeaders] = strconv.FormatBool(dlq.ContextHeaders)
+	}
+	return cfg, nil
+}
+
+// secretRefs returns the Secret keys referenced by typed fields.
+func secretRefs(p v1alpha1.ConnectorParameters) []xpv1.SecretKeySelector {
+	var refs []xpv1.SecretKeySelector
+	for _, role := range []string{roleKey, roleValue, roleHeader} {
+		if cv := converter(p.Converters, role); cv != nil && cv.SchemaRegistryCredentialsSecretRef != nil {
+			refs = append(refs, *cv.SchemaRegistryCredentialsSecretRef)
+		}
+	}
+	return refs
+}
+
+// converter returns the converter of the supplied role, if configured.
+func converter(c *v1alpha1.Converters, role string) *v1alpha1.Converter {
+	if c == nil {
+		return nil
+	}
+	switch role {
+	case roleKey:
+		return c.Key
+	case roleValue:
+		return c.Value
+	case roleHeader:
+		return c.Header
+	}
+	return nil
+}
+
+// converterPrefix returns the config key of the converter of the supplied
+// role, which prefixes its config.
+func converterPrefix(role string) string {
+	return role + ".converter"
+}
+
+// renderConverter returns a function that renders the converter of the
+// supplied role into the <role>.converter key naming its class, and the
+// <role>.converter.* keys configuring it.
+func renderConverter(role string) func(p v1alpha1.ConnectorParameters, secrets secretValues) (map[string]string, error) {
+	return func(p v1alpha1.ConnectorParameters, secrets secretValues) (map[string]string, error) {
+		cv := converter(p.Converters, role)
+		if cv == nil {
+			return nil, nil
+		}
+
+		prefix := converterPrefix(role)
+		cfg := map[string]string{prefix: cv.Class}
+		for k, v := range cv.Config {
+			cfg[prefix+"."+k] = v
+		}
+
+		ref := cv.SchemaRegistryCredentialsSecretRef
+		if ref == nil {
+			return cfg, nil
+		}
+		for _, k := range []string{keyConverterAuthSource, keyConverterAuthUserInfo} {
+			if _, ok := cv.Config[k]; ok {
+				return nil, errors.Errorf(errFmtReservedKey, "converter", role, k)
+			}
+		}
+		userInfo, ok := secrets[*ref]
+		if !ok {
+			return nil, errors.Errorf(errFmtUnresolved, ref.Namespace, ref.Name, ref.Key)
+		}
+		cfg[prefix+"."+keyConverterAuthSource] = authSourceUserInfo
+		cfg[prefix+"."+keyConverterAuthUserInfo] = userInfo
+		return cfg, nil
+	}
+}
+
+// renderTopicCreation renders topic creation into the topic.creation.groups
+// key listing the groups other than the default one in order, and the
+// topic.creation.<group>.* keys configuring each.
+func renderTopicCreation(p v1alpha1.ConnectorParameters, _ secretValues) (map[string]string, error) {
+	tc := p.TopicCreation
+	if tc == nil {
+		return nil, nil
+	}
+
+	cfg := map[string]string{}
+	d := tc.Default
+	if err := renderTopicConfig(cfg, groupDefault, d.Config); err != nil {
+		return nil, err
+	}
+	prefix := keyTopicCreation + "." + groupDefault + "."
+	cfg[prefix+keyGroupReplicationFactor] = strconv.Itoa(d.ReplicationFactor)
+	cfg[prefix+keyGroupPartitions] = strconv.Itoa(d.Partitions)
+
+	names := make([]string, 0, len(tc.Groups))
+	for _, g := range tc.Groups {
+		if g.Name == groupDefault || slices.Contains(names, g.Name) {
+			return nil, errors.Errorf(errFmtDuplicate, "topic creation group", g.Name)
+		}
+		names = append(names, g.Name)
+
+		if err := renderTopicConfig(cfg, g.Name, g.Config); err != nil {
+			return nil, err
+		}
+		prefix := keyTopicCreation + "." + g.Name + "."
+		cfg[prefix+keyGroupInclude] = strings.Join(g.Include, ",")
+		if len(g.Exclude) > 0 {
+			cfg[prefix+keyGroupExclude] = strings.Join(g.Exclude, ",")
+		}
+		if g.ReplicationFactor != nil {
+			cfg[prefix+keyGroupReplicationFactor] = strconv.Itoa(*g.ReplicationFactor)
+		}
+		if g.Partitions != nil {
+			cfg[prefix+keyGroupPartitions] = strconv.Itoa(*g.Partitions)
+		}
+	}
+	if len(names) > 0 {
+		cfg[keyTopicCreationGroups] = strings.Join(names, ",")
+	}
+	return cfg, nil
+}
+
+// renderTopicConfig renders the topic config of the named topic creation
+// group into the supplied connector config.
+func renderTopicConfig(cfg map[string]string, group string, config map[string]string) error {
+	prefix := keyTopicCreation + "." + group + "."
+	for k, v := range config {
+		switch k {
+		case keyGroupReplicationFactor, keyGroupPartitions, keyGroupInclude, keyGroupExclude:
+			return errors.Errorf(errFmtReservedKey, "topic creation group", group, k)
+		}
+		cfg[prefix+k] = v
+	}
+	return nil
+}
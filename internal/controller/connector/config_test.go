@@ -0,0 +1,305 @@
+/*
+Copyright 2025 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package connector
+
+import (
+	"encoding/json"
+	"maps"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/pkg/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+
+	"github.com/crossplane/provider-kafkaconnect/apis/kafkaconnect/v1alpha1"
+)
+
+func withTransforms(t ...v1alpha1.Transform) connectorModifier {
+	return func(cr *v1alpha1.Connector) { cr.Spec.ForProvider.Transforms = t }
+}
+
+func withPredicates(p ...v1alpha1.Predicate) connectorModifier {
+	return func(cr *v1alpha1.Connector) { cr.Spec.ForProvider.Predicates = p }
+}
+
+func withErrorHandling(eh *v1alpha1.ErrorHandling) connectorModifier {
+	return func(cr *v1alpha1.Connector) { cr.Spec.ForProvider.ErrorHandling = eh }
+}
+
+func withConverters(c *v1alpha1.Converters) connectorModifier {
+	return func(cr *v1alpha1.Connector) { cr.Spec.ForProvider.Converters = c }
+}
+
+func withTopicCreation(tc *v1alpha1.TopicCreation) connectorModifier {
+	return func(cr *v1alpha1.Connector) { cr.Spec.ForProvider.TopicCreation = tc }
+}
+
+func TestDesiredConfig(t *testing.T) {
+	type want struct {
+		cfg map[string]string
+		err error
+	}
+
+	registryCreds := xpv1.SecretKeySelector{SecretReference: xpv1.SecretReference{Namespace: "ns", Name: "registry"}, Key: "userinfo"}
+	base := map[string]string{"name": "a", "connector.class": class, "tasks.max": "1", "file": "/tmp/a"}
+	with := func(kv ...string) map[string]string {
+		cfg := maps.Clone(base)
+		for i := 0; i < len(kv); i += 2 {
+			cfg[kv[i]] = kv[i+1]
+		}
+		return cfg
+	}
+
+	cases := map[string]struct {
+		reason  string
+		cr      *v1alpha1.Connector
+		secrets secretValues
+		want    want
+	}{
+		"Plain": {
+			reason: "The config should include the name, class and maximum number of tasks.",
+			cr:     newConnector(),
+			want:   want{cfg: base},
+		},
+		"TransformsAndPredicates": {
+			reason: "Transforms and predicates should be rendered in order, with their config, predicate and negation.",
+			cr: newConnector(
+				withTransforms(
+					v1alpha1.Transform{Name: "route", Type: "org.apache.kafka.connect.transforms.RegexRouter", Config: map[string]string{"regex": "(.*)", "replacement": "x-$1"}},
+					v1alpha1.Transform{Name: "drop", Type: "org.apache.kafka.connect.transforms.Filter", Predicate: "tombstone", Negate: true},
+				),
+				withPredicates(v1alpha1.Predicate{Name: "tombstone", Type: "org.apache.kafka.connect.transforms.predicates.RecordIsTombstone"}),
+			),
+			want: want{cfg: with(
+				"transforms", "route,drop",
+				"transforms.route.type", "org.apache.kafka.connect.transforms.RegexRouter",
+				"transforms.route.regex", "(.*)",
+				"transforms.route.replacement", "x-$1",
+				"transforms.drop.type", "org.apache.kafka.connect.transforms.Filter",
+				"transforms.drop.predicate", "tombstone",
+				"transforms.drop.negate", "true",
+				"predicates", "tombstone",
+				"predicates.tombstone.type", "org.apache.kafka.connect.transforms.predicates.RecordIsTombstone",
+			)},
+		},
+		"ManualTransforms": {
+			reason: "Transforms may still be configured by hand if no typed transforms are.",
+			cr:     newConnector(withConfig("transforms", "a"), withConfig("transforms.a.type", "T")),
+			want:   want{cfg: with("transforms", "a", "transforms.a.type", "T")},
+		},
+		"Conflict": {
+			reason: "Config may not set keys owned by typed transforms.",
+			cr: newConnector(
+				withConfig("transforms.route.regex", ".*"),
+				withTransforms(v1alpha1.Transform{Name: "route", Type: "T"}),
+			),
+			want: want{err: errors.Wrap(errors.Errorf(errFmtConflict, "transforms.route.regex", "transforms"), errRenderConfig)},
+		},
+		"ReservedKey": {
+			reason: "The config of a transform may not set the keys rendered from its fields.",
+			cr:     newConnector(withTransforms(v1alpha1.Transform{Name: "route", Type: "T", Config: map[string]string{"type": "U"}})),
+			want:   want{err: errors.Wrap(errors.Errorf(errFmtReservedKey, "transform", "route", "type"), errRenderConfig)},
+		},
+		"UnknownPredicate": {
+			reason: "Transforms may only reference configured predicates.",
+			cr:     newConnector(withTransforms(v1alpha1.Transform{Name: "route", Type: "T", Predicate: "missing"})),
+			want:   want{err: errors.Wrap(errors.Errorf(errFmtNoPredicate, "route", "missing"), errRenderConfig)},
+		},
+		"DuplicatePredicate": {
+			reason: "Predicate names must be unique.",
+			cr:     newConnector(withPredicates(v1alpha1.Predicate{Name: "p", Type: "T"}, v1alpha1.Predicate{Name: "p", Type: "U"})),
+			want:   want{err: errors.Wrap(errors.Errorf(errFmtDuplicate, "predicate", "p"), errRenderConfig)},
+		},
+		"ErrorHandling": {
+			reason: "Error handling should be rendered into the errors keys, with durations in milliseconds.",
+			cr: newConnector(withErrorHandling(&v1alpha1.ErrorHandling{
+				Tolerance:     "all",
+				RetryTimeout:  &metav1.Duration{Duration: 10 * time.Minute},
+				RetryDelayMax: &metav1.Duration{Duration: 30 * time.Second},
+				Log:           &v1alpha1.ErrorLog{Enable: true},
+				DeadLetterQueue: &v1alpha1.DeadLetterQueue{
+					TopicName:         "dlq",
+					ReplicationFactor: ptr.To(3),
+					ContextHeaders:    true,
+				},
+			})),
+			want: want{cfg: with(
+				"errors.tolerance", "all",
+				"errors.retry.timeout", "600000",
+				"errors.retry.delay.max.ms", "30000",
+				"errors.log.enable", "true",
+				"errors.log.include.messages", "false",
+				"errors.deadletterqueue.topic.name", "dlq",
+				"errors.deadletterqueue.topic.replication.factor", "3",
+				"errors.deadletterqueue.context.headers.enable", "true",
+			)},
+		},
+		"RetryForever": {
+			reason: "Negative retry timeouts should be rendered as -1, and the tolerance should default to none.",
+			cr:     newConnector(withErrorHandling(&v1alpha1.ErrorHandling{RetryTimeout: &metav1.Duration{Duration: -time.Second}})),
+			want:   want{cfg: with("errors.tolerance", "none", "errors.retry.timeout", "-1")},
+		},
+		"DeadLetterQueueWithoutTolerance": {
+			reason: "A dead letter queue should require tolerance all, since it is never written to otherwise.",
+			cr:     newConnector(withErrorHandling(&v1alpha1.ErrorHandling{DeadLetterQueue: &v1alpha1.DeadLetterQueue{TopicName: "dlq"}})),
+			want:   want{err: errors.Wrap(errors.New(errDLQTolerance), errRenderConfig)},
+		},
+		"ErrorHandlingConflict": {
+			reason: "Config may not set errors keys if error handling is configured.",
+			cr:     newConnector(withConfig("errors.tolerance", "all"), withErrorHandling(&v1alpha1.ErrorHandling{Tolerance: "all"})),
+			want:   want{err: errors.Wrap(errors.Errorf(errFmtConflict, "errors.tolerance", "errorHandling"), errRenderConfig)},
+		},
+		"Converters": {
+			reason: "Converters should be rendered into their prefixed keys, with schema registry credentials from their secret.",
+			cr: newConnector(withConverters(&v1alpha1.Converters{
+				Key: &v1alpha1.Converter{Class: "org.apache.kafka.connect.storage.StringConverter"},
+				Value: &v1alpha1.Converter{
+					Class:                              "io.confluent.connect.avro.AvroConverter",
+					Config:                             map[string]string{"schema.registry.url": "https://registry:8081"},
+					SchemaRegistryCredentialsSecretRef: &registryCreds,
+				},
+			})),
+			secrets: secretValues{registryCreds: "user:pass"},
+			want: want{cfg: with(
+				"key.converter", "org.apache.kafka.connect.storage.StringConverter",
+				"value.converter", "io.confluent.connect.avro.AvroConverter",
+				"value.converter.schema.registry.url", "https://registry:8081",
+				"value.converter.basic.auth.credentials.source", "USER_INFO",
+				"value.converter.basic.auth.user.info", "user:pass",
+			)},
+		},
+		"ManualConverter": {
+			reason: "Converters that are not configured may still be configured by hand.",
+			cr: newConnector(
+				withConfig("key.converter", "org.apache.kafka.connect.storage.StringConverter"),
+				withConverters(&v1alpha1.Converters{Value: &v1alpha1.Converter{Class: "org.apache.kafka.connect.json.JsonConverter"}}),
+			),
+			want: want{cfg: with(
+				"key.converter", "org.apache.kafka.connect.storage.StringConverter",
+				"value.converter", "org.apache.kafka.connect.json.JsonConverter",
+			)},
+		},
+		"ConverterConflict": {
+			reason: "Config may not set keys of a configured converter.",
+			cr: newConnector(
+				withConfig("value.converter.schemas.enable", "false"),
+				withConverters(&v1alpha1.Converters{Value: &v1alpha1.Converter{Class: "org.apache.kafka.connect.json.JsonConverter"}}),
+			),
+			want: want{err: errors.Wrap(errors.Errorf(errFmtConflict, "value.converter.schemas.enable", "converters.value"), errRenderConfig)},
+		},
+		"UnresolvedCredentials": {
+			reason: "Schema registry credentials must have been resolved.",
+			cr: newConnector(withConverters(&v1alpha1.Converters{
+				Value: &v1alpha1.Converter{Class: "io.confluent.connect.avro.AvroConverter", SchemaRegistryCredentialsSecretRef: &registryCreds},
+			})),
+			want: want{err: errors.Wrap(errors.Errorf(errFmtUnresolved, "ns", "registry", "userinfo"), errRenderConfig)},
+		},
+		"TopicCreation": {
+			reason: "Topic creation should be rendered into the default group and the named groups in order.",
+			cr: newConnector(withTopicCreation(&v1alpha1.TopicCreation{
+				Default: v1alpha1.TopicCreationDefault{ReplicationFactor: 3, Partitions: -1},
+				Groups: []v1alpha1.TopicCreationGroup{
+					{Name: "compacted", Include: []string{"state-.*", "config-.*"}, Exclude: []string{"state-tmp-.*"}, Config: map[string]string{"cleanup.policy": "compact"}},
+					{Name: "big", Include: []string{"events-.*"}, Partitions: ptr.To(12)},
+				},
+			})),
+			want: want{cfg: with(
+				"topic.creation.default.replication.factor", "3",
+				"topic.creation.default.partitions", "-1",
+				"topic.creation.groups", "compacted,big",
+				"topic.creation.compacted.include", "state-.*,config-.*",
+				"topic.creation.compacted.exclude", "state-tmp-.*",
+				"topic.creation.compacted.cleanup.policy", "compact",
+				"topic.creation.big.include", "events-.*",
+				"topic.creation.big.partitions", "12",
+			)},
+		},
+		"TopicCreationDefaultGroup": {
+			reason: "The default group may not be configured as a named group.",
+			cr: newConnector(withTopicCreation(&v1alpha1.TopicCreation{
+				Default: v1alpha1.TopicCreationDefault{ReplicationFactor: 3, Partitions: 1},
+				Groups:  []v1alpha1.TopicCreationGroup{{Name: "default", Include: []string{".*"}}},
+			})),
+			want: want{err: errors.Wrap(errors.Errorf(errFmtDuplicate, "topic creation group", "default"), errRenderConfig)},
+		},
+		"TopicCreationReservedKey": {
+			reason: "The topic config of a group may not set the keys rendered from its fields.",
+			cr: newConnector(withTopicCreation(&v1alpha1.TopicCreation{
+				Default: v1alpha1.TopicCreationDefault{ReplicationFactor: 3, Partitions: 1, Config: map[string]string{"partitions": "2"}},
+			})),
+			want: want{err: errors.Wrap(errors.Errorf(errFmtReservedKey, "topic creation group", "default", "partitions"), errRenderConfig)},
+		},
+		"NonStringValues": {
+			reason: "Numbers, booleans and lists should be rendered in their canonical string form.",
+			cr: newConnector(
+				withConfig("batch.size", 500),
+				withConfig("offset.start", json.RawMessage("18446744073709551615")),
+				withConfig("poll.interval.ms", json.RawMessage("1.5e3")),
+				withConfig("tasks.retries", json.RawMessage("2.0")),
+				withConfig("ratio", 0.25),
+				withConfig("schemas.enable", false),
+				withConfig("topics", []any{"a", "b", 3}),
+			),
+			want: want{cfg: with(
+				"batch.size", "500",
+				"offset.start", "18446744073709551615",
+				"poll.interval.ms", "1500",
+				"tasks.retries", "2",
+				"ratio", "0.25",
+				"schemas.enable", "false",
+				"topics", "a,b,3",
+			)},
+		},
+		"FieldKey": {
+			reason: "Config may not set keys that are set by their own fields.",
+			cr:     newConnector(withConfig("tasks.max", "2")),
+			want:   want{err: errors.Wrap(errors.Errorf(errFmtConflict, "tasks.max", "tasksMax"), errRenderConfig)},
+		},
+		"NullValue": {
+			reason: "Null is not a valid config value.",
+			cr:     newConnector(withConfig("nothing", nil)),
+			want:   want{err: errors.Wrap(errors.Wrapf(errors.New(errValueType), errFmtConfigValue, "nothing"), errRenderConfig)},
+		},
+		"ObjectValue": {
+			reason: "Objects are not valid config values.",
+			cr:     newConnector(withConfig("nested", map[string]string{"a": "b"})),
+			want:   want{err: errors.Wrap(errors.Wrapf(errors.New(errValueType), errFmtConfigValue, "nested"), errRenderConfig)},
+		},
+		"ListComma": {
+			reason: "List elements containing commas cannot be joined unambiguously.",
+			cr:     newConnector(withConfig("topics", []string{"a,b"})),
+			want:   want{err: errors.Wrap(errors.Wrapf(errors.New(errListComma), errFmtConfigValue, "topics"), errRenderConfig)},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got, err := desiredConfig(tc.cr, tc.secrets)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\ndesiredConfig(...): -want error, +got error:\n%s\n", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.cfg, got); diff != "" {
+				t.Errorf("\n%s\ndesiredConfig(...): -want, +got:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
@@ -18,18 +18,23 @@ package connector
 
 import (
 	"context"
-	"fmt"
+	"maps"
+	"time"
 
 	"github.com/crossplane/crossplane-runtime/pkg/feature"
 
 	"github.com/pkg/errors"
+	"go.opentelemetry.io/otel/trace"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
 	"github.com/crossplane/crossplane-runtime/pkg/connection"
-	"github.com/crossplane/crossplane-runtime/pkg/controller"
 	"github.com/crossplane/crossplane-runtime/pkg/event"
+	"github.com/crossplane/crossplane-runtime/pkg/meta"
 	"github.com/crossplane/crossplane-runtime/pkg/ratelimiter"
 	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
 	"github.com/crossplane/crossplane-runtime/pkg/resource"
@@ -37,29 +42,83 @@ import (
 
 	"github.com/crossplane/provider-kafkaconnect/apis/kafkaconnect/v1alpha1"
 	apisv1alpha1 "github.com/crossplane/provider-kafkaconnect/apis/v1alpha1"
+	"github.com/crossplane/provider-kafkaconnect/internal/clients/kafkaconnect"
 	"github.com/crossplane/provider-kafkaconnect/internal/features"
+	"github.com/crossplane/provider-kafkaconnect/internal/metrics"
+	"github.com/crossplane/provider-kafkaconnect/internal/options"
+	"github.com/crossplane/provider-kafkaconnect/internal/tracing"
 )
 
 const (
-	errNotConnector    = "managed resource is not a Connector custom resource"
+	errNotConnector = "managed resource is not a Connector custom resource"
 	errTrackPCUsage = "cannot track ProviderConfig usage"
 	errGetPC        = "cannot get ProviderConfig"
 	errGetCreds     = "cannot get credentials"
+	errMismatch     = "ProviderConfig points at an unexpected Kafka Connect cluster"
+	errURL          = "cannot use Kafka Connect URL"
+	errResolve      = "cannot resolve Kafka Connect workers"
+	errGetSecrets   = "cannot get referenced secrets"
+	errFmtGetSecret = "cannot get secret %s/%s"
+	errFmtNoKey     = "secret %s/%s has no key %q"
 
 	errNewClient = "cannot create new Service"
+
+	errGetConnector       = "cannot get connector"
+	errGetConnectorStatus = "cannot get connector status"
+	errCreateConnector    = "cannot create connector"
+	errUpdateConnector    = "cannot update connector"
+	errRestartConnector   = "cannot restart connector"
+	errDeleteConnector    = "cannot delete connector"
+)
+
+// Kafka Connect connector configuration keys managed through dedicated
+// ConnectorParameters fields.
+const (
+	keyName           = "name"
+	keyConnectorClass = "connector.class"
+	keyTasksMax       = "tasks.max"
+)
+
+// Kafka Connect connector states.
+const (
+	stateRunning = "RUNNING"
+	stateFailed  = "FAILED"
+
+	// defaultRestartInterval is the minimum time between two automated
+	// restarts if the Connector does not configure one.
+	defaultRestartInterval = 5 * time.Minute
 )
 
-// A NoOpService does nothing.
-type NoOpService struct{}
+// A Service is the subset of the Kafka Connect API used to manage connectors.
+type Service interface {
+	CreateConnector(ctx context.Context, config kafkaconnect.ConnectorConfig) (*kafkaconnect.ConnectorInfo, error)
+	GetConnector(ctx context.Context, name string) (*kafkaconnect.ConnectorInfo, error)
+	UpdateConnector(ctx context.Context, name string, config map[string]string) (*kafkaconnect.ConnectorInfo, error)
+	DeleteConnector(ctx context.Context, name string) error
+	GetConnectorStatus(ctx context.Context, name string) (*kafkaconnect.ConnectorStatus, error)
+	ListConnectorsExpanded(ctx context.Context) (map[string]kafkaconnect.ExpandedConnector, error)
+	RestartConnector(ctx context.Context, name string, includeTasks, onlyFailed bool) error
+	RestartTask(ctx context.Context, name string, task int) error
+}
 
 var (
-	newNoOpService = func(_ []byte) (interface{}, error) { return &NoOpService{}, nil }
+	newKafkaConnectService = func(pc *apisv1alpha1.ProviderConfig, creds []byte, opts ...kafkaconnect.ClientOption) (Service, error) {
+		return kafkaconnect.NewFromProviderConfig(pc, creds, opts...)
+	}
 )
 
 // Setup adds a controller that reconciles Connector managed resources.
-func Setup(mgr ctrl.Manager, o controller.Options) error {
+func Setup(mgr ctrl.Manager, o options.Options) error {
 	name := managed.ControllerName(v1alpha1.ConnectorGroupKind)
 
+	var poller *statusPoller
+	if o.StatusPollInterval > 0 {
+		poller = newStatusPoller(mgr.GetClient(), o.Logger.WithValues("controller", name), o.StatusPollInterval)
+		if err := mgr.Add(poller); err != nil {
+			return errors.Wrap(err, "cannot register connector status poller")
+		}
+	}
+
 	cps := []managed.ConnectionPublisher{managed.NewAPISecretPublisher(mgr.GetClient(), mgr.GetScheme())}
 	if o.Features.Enabled(features.EnableAlphaExternalSecretStores) {
 		cps = append(cps, connection.NewDetailsManager(mgr.GetClient(), apisv1alpha1.StoreConfigGroupVersionKind))
@@ -69,7 +128,9 @@ func Setup(mgr ctrl.Manager, o controller.Options) error {
 		managed.WithExternalConnecter(&connector{
 			kube:         mgr.GetClient(),
 			usage:        resource.NewProviderConfigUsageTracker(mgr.GetClient(), &apisv1alpha1.ProviderConfigUsage{}),
-			newServiceFn: newNoOpService}),
+			newServiceFn: newKafkaConnectService,
+			services:     newServiceCache(),
+			poller:       poller}),
 		managed.WithLogger(o.Logger.WithValues("controller", name)),
 		managed.WithPollInterval(o.PollInterval),
 		managed.WithRecorder(event.NewAPIRecorder(mgr.GetEventRecorderFor(name))),
@@ -96,12 +157,19 @@ func Setup(mgr ctrl.Manager, o controller.Options) error {
 
 	r := managed.NewReconciler(mgr, resource.ManagedKind(v1alpha1.ConnectorGroupVersionKind), opts...)
 
-	return ctrl.NewControllerManagedBy(mgr).
+	b := ctrl.NewControllerManagedBy(mgr).
 		Named(name).
 		WithOptions(o.ForControllerRuntime()).
 		WithEventFilter(resource.DesiredStateChanged()).
-		For(&v1alpha1.Connector{}).
-		Complete(ratelimiter.NewReconciler(name, r, o.GlobalRateLimiter))
+		For(&v1alpha1.Connector{})
+	if poller != nil {
+		b = b.WatchesRawSource(poller.Source())
+	}
+	return b.Complete(ratelimiter.NewReconciler(name, &syncedReconciler{
+		Reconciler: r,
+		kube:       mgr.GetClient(),
+		newObject:  func() resource.Managed { return &v1alpha1.Connector{} },
+	}, o.GlobalRateLimiter))
 }
 
 // A connector is expected to produce an ExternalClient when its Connect method
@@ -109,7 +177,14 @@ func Setup(mgr ctrl.Manager, o controller.Options) error {
 type connector struct {
 	kube         client.Client
 	usage        resource.Tracker
-	newServiceFn func(creds []byte) (interface{}, error)
+	newServiceFn func(pc *apisv1alpha1.ProviderConfig, creds []byte, opts ...kafkaconnect.ClientOption) (Service, error)
+
+	// services caches services per ProviderConfig. Services are created
+	// for each Connect if it is nil.
+	services *serviceCache
+
+	// poller is nil if connectors are observed individually.
+	poller *statusPoller
 }
 
 // Connect typically produces an ExternalClient by:
@@ -117,110 +192,483 @@ type connector struct {
 // 2. Getting the managed resource's ProviderConfig.
 // 3. Getting the credentials specified by the ProviderConfig.
 // 4. Using the credentials to form a client.
-func (c *connector) Connect(ctx context.Context, mg resource.Managed) (managed.ExternalClient, error) {
+func (c *connector) Connect(ctx context.Context, mg resource.Managed) (_ managed.ExternalClient, err error) {
 	cr, ok := mg.(*v1alpha1.Connector)
 	if !ok {
 		return nil, errors.New(errNotConnector)
 	}
 
+	ctx, span := startSpan(ctx, "Connect", cr)
+	defer func() { tracing.End(span, err) }()
+
 	if err := c.usage.Track(ctx, mg); err != nil {
 		return nil, errors.Wrap(err, errTrackPCUsage)
 	}
 
 	pc := &apisv1alpha1.ProviderConfig{}
-	if err := c.kube.Get(ctx, types.NamespacedName{Name: cr.GetProviderConfigReference().Name}, pc); err != nil {
+	if err := c.getProviderConfig(ctx, cr, pc); err != nil {
 		return nil, errors.Wrap(err, errGetPC)
 	}
+	e, err := c.connect(ctx, span, cr, pc)
+	if err != nil {
+		return nil, err
+	}
+	return e, nil
+}
 
-	cd := pc.Spec.Credentials
-	data, err := resource.CommonCredentialExtractor(ctx, cd.Source, c.kube, cd.CommonCredentialSelectors)
+// connect produces an ExternalClient for the supplied Connector from the
+// supplied ProviderConfig, which the Connector is tracked as using.
+func (c *connector) connect(ctx context.Context, span trace.Span, cr *v1alpha1.Connector, pc *apisv1alpha1.ProviderConfig) (*external, error) {
+	span.SetAttributes(tracing.AttributeProviderConfig.String(pc.GetName()))
+
+	override := cr.Spec.ForProvider.KafkaConnectURL
+	if override != "" {
+		if err := kafkaconnect.AllowedURL(pc, override); err != nil {
+			return nil, errors.Wrap(err, errURL)
+		}
+	}
+
+	// Stop reconciling while the ProviderConfig's health check reports that
+	// it points at the wrong cluster. The client guards mutating calls too,
+	// in case the ProviderConfig changed since it was last checked, and for
+	// overrides, which the health check does not cover.
+	if c := pc.GetCondition(xpv1.TypeReady); override == "" && c.Reason == apisv1alpha1.ReasonClusterMismatch {
+		err := errors.Wrap(errors.New(c.Message), errMismatch)
+		cr.SetConditions(v1alpha1.ClusterMismatch(err))
+		return nil, err
+	}
+
+	// Fail fast while the circuit breaker of the cluster is open, rather
+	// than once per request.
+	breaker := pc.GetName()
+	if override != "" {
+		breaker = kafkaconnect.OverrideBreaker(pc.GetName(), override)
+	}
+	if err := kafkaconnect.BreakerFor(breaker).Err(); err != nil {
+		cr.SetConditions(v1alpha1.ClusterUnavailable(err))
+		return nil, err
+	}
+
+	data, err := c.extractCredentials(ctx, pc)
 	if err != nil {
 		return nil, errors.Wrap(err, errGetCreds)
 	}
 
-	svc, err := c.newServiceFn(data)
+	// The secrets referenced by a connector being deleted are not needed,
+	// and must not block its deletion if they are gone.
+	var secrets secretValues
+	if !meta.WasDeleted(cr) {
+		if secrets, err = c.resolveSecrets(ctx, cr); err != nil {
+			return nil, errors.Wrap(err, errGetSecrets)
+		}
+	}
+
+	svc, release, err := c.service(ctx, pc, data, override)
 	if err != nil {
-		return nil, errors.Wrap(err, errNewClient)
+		return nil, err
+	}
+
+	e := &external{service: svc, release: release, providerConfig: pc.GetName(), url: effectiveURL(pc, override), secrets: secrets}
+
+	// The status of connectors on an overridden URL is not polled, since they
+	// may live on another cluster than the ProviderConfig's.
+	if c.poller != nil && override == "" {
+		e.cache = c.poller.ForProviderConfig(pc.GetName(), svc)
+	}
+	return e, nil
+}
+
+// service returns a service for the workers of a Connector: its override, or
+// else the workers of its ProviderConfig. Services are cached if possible.
+// The returned function releases the service.
+func (c *connector) service(ctx context.Context, pc *apisv1alpha1.ProviderConfig, creds []byte, override string) (Service, func(), error) {
+	var workers []string
+	opts := []kafkaconnect.ClientOption{kafkaconnect.WithURL(override)}
+	if override == "" {
+		var err error
+		if workers, err = kafkaconnect.ResolveWorkers(ctx, c.kube, pc); err != nil {
+			return nil, nil, errors.Wrap(err, errResolve)
+		}
+		opts = []kafkaconnect.ClientOption{kafkaconnect.WithWorkers(workers...)}
+	}
+
+	newFn := func() (Service, error) { return c.newServiceFn(pc, creds, opts...) }
+	if c.services == nil {
+		svc, err := newFn()
+		return svc, func() {}, errors.Wrap(err, errNewClient)
 	}
 
-	return &external{service: svc}, nil
+	svc, release, err := c.services.Get(serviceKey{uid: pc.GetUID(), url: override}, serviceHash(pc, creds, workers), newFn)
+	return svc, release, errors.Wrap(err, errNewClient)
+}
+
+// effectiveURL returns the URL of the Kafka Connect worker a Connector is
+// managed through: its override, or else its ProviderConfig's active worker.
+func effectiveURL(pc *apisv1alpha1.ProviderConfig, override string) string {
+	if override != "" {
+		return override
+	}
+	if active := kafkaconnect.ActiveEndpoint(pc.GetName()); active != "" {
+		return active
+	}
+	if urls := pc.Endpoints(); len(urls) > 0 {
+		return urls[0]
+	}
+	return ""
+}
+
+func (c *connector) getProviderConfig(ctx context.Context, cr *v1alpha1.Connector, pc *apisv1alpha1.ProviderConfig) (err error) {
+	ctx, span := tracing.Start(ctx, "GetProviderConfig")
+	defer func() { tracing.End(span, err) }()
+
+	return c.kube.Get(ctx, types.NamespacedName{Name: cr.GetProviderConfigReference().Name}, pc)
+}
+
+func (c *connector) extractCredentials(ctx context.Context, pc *apisv1alpha1.ProviderConfig) (_ []byte, err error) {
+	ctx, span := tracing.Start(ctx, "ExtractCredentials")
+	defer func() { tracing.End(span, err) }()
+
+	cd := pc.Spec.Credentials
+	return resource.CommonCredentialExtractor(ctx, cd.Source, c.kube, cd.CommonCredentialSelectors)
+}
+
+// resolveSecrets returns the values of the Secret keys referenced by the
+// typed fields of the supplied Connector.
+func (c *connector) resolveSecrets(ctx context.Context, cr *v1alpha1.Connector) (_ secretValues, err error) {
+	refs := secretRefs(cr.Spec.ForProvider)
+	if len(refs) == 0 {
+		return nil, nil
+	}
+
+	ctx, span := tracing.Start(ctx, "ResolveSecrets")
+	defer func() { tracing.End(span, err) }()
+
+	values := make(secretValues, len(refs))
+	for _, ref := range refs {
+		s := &corev1.Secret{}
+		if err := c.kube.Get(ctx, types.NamespacedName{Namespace: ref.Namespace, Name: ref.Name}, s); err != nil {
+			return nil, errors.Wrapf(err, errFmtGetSecret, ref.Namespace, ref.Name)
+		}
+		v, ok := s.Data[ref.Key]
+		if !ok {
+			return nil, errors.Errorf(errFmtNoKey, ref.Namespace, ref.Name, ref.Key)
+		}
+		values[ref] = string(v)
+	}
+	return values, nil
 }
 
 // An ExternalClient observes, then either creates, updates, or deletes an
 // external resource to ensure it reflects the managed resource's desired state.
 type external struct {
-	// A 'client' used to connect to the external resource API. In practice this
-	// would be something like an AWS SDK client.
-	service interface{}
+	service Service
+
+	// release the service once the external client is disconnected.
+	release func()
+
+	// providerConfig is the name of the ProviderConfig the service was
+	// created from. It is used to label metrics.
+	providerConfig string
+
+	// url is the URL of the Kafka Connect cluster the service talks to.
+	url string
+
+	// secrets are the values of the Secret keys referenced by the connector.
+	secrets secretValues
+
+	// cache of the connectors of the Kafka Connect cluster. Connectors are
+	// observed individually if it is nil or misses.
+	cache *clusterPoller
+
+	// configUpToDate and failed record what Observe found for Update: whether
+	// the config of the connector is up to date, and the status of the
+	// connector if it failed and is due to be restarted.
+	configUpToDate bool
+	failed         *kafkaconnect.ConnectorStatus
 }
 
-func (c *external) Observe(ctx context.Context, mg resource.Managed) (managed.ExternalObservation, error) {
+func (c *external) Observe(ctx context.Context, mg resource.Managed) (_ managed.ExternalObservation, err error) {
 	cr, ok := mg.(*v1alpha1.Connector)
 	if !ok {
 		return managed.ExternalObservation{}, errors.New(errNotConnector)
 	}
 
-	// These fmt statements should be removed in the real implementation.
-	fmt.Printf("Observing: %+v", cr)
+	ctx, span := c.startSpan(ctx, "Observe", cr)
+	defer func() { tracing.End(span, err) }()
+	defer func() { setClusterCondition(cr, err) }()
+
+	name := connectorName(cr)
+	info, status, err := c.observe(ctx, name)
+	if kafkaconnect.IsNotFound(err) {
+		return managed.ExternalObservation{ResourceExists: false}, nil
+	}
+	if err != nil {
+		return managed.ExternalObservation{}, err
+	}
+
+	lastRestart := cr.Status.AtProvider.LastRestartTime
+	cr.Status.AtProvider = generateObservation(status)
+	cr.Status.AtProvider.KafkaConnectURL = c.url
+	cr.Status.AtProvider.LastRestartTime = lastRestart
+	c.recordState(name, status)
+
+	switch status.Connector.State {
+	case stateRunning:
+		cr.SetConditions(xpv1.Available())
+	default:
+		cr.SetConditions(xpv1.Unavailable())
+	}
+
+	// The config of a connector being deleted does not matter, and must not
+	// block its deletion if it is invalid.
+	upToDate := true
+	if !meta.WasDeleted(cr) {
+		desired, err := desiredConfig(cr, c.secrets)
+		if err != nil {
+			return managed.ExternalObservation{}, err
+		}
+		upToDate = maps.Equal(desired, info.Config)
+		if restartDue(cr, status, time.Now()) {
+			c.failed = status
+		}
+	}
+	c.configUpToDate = upToDate
 
 	return managed.ExternalObservation{
-		// Return false when the external resource does not exist. This lets
-		// the managed resource reconciler know that it needs to call Create to
-		// (re)create the resource, or that it has successfully been deleted.
-		ResourceExists: true,
-
-		// Return false when the external resource exists, but it not up to date
-		// with the desired managed resource state. This lets the managed
-		// resource reconciler know that it needs to call Update.
-		ResourceUpToDate: true,
-
-		// Return any details that may be required to connect to the external
-		// resource. These will be stored as the connection secret.
-		ConnectionDetails: managed.ConnectionDetails{},
+		ResourceExists:   true,
+		ResourceUpToDate: upToDate && c.failed == nil,
 	}, nil
 }
 
-func (c *external) Create(ctx context.Context, mg resource.Managed) (managed.ExternalCreation, error) {
+// restartDue returns true if the supplied Connector restarts its connector
+// automatically, the connector or one of its tasks failed, and it was not
+// restarted within the minimum interval.
+func restartDue(cr *v1alpha1.Connector, status *kafkaconnect.ConnectorStatus, now time.Time) bool {
+	ar := cr.Spec.ForProvider.AutoRestart
+	if ar == nil || !ar.Enabled {
+		return false
+	}
+	failed := status.Connector.State == stateFailed
+	for _, t := range status.Tasks {
+		failed = failed || t.State == stateFailed
+	}
+	if !failed {
+		return false
+	}
+	interval := defaultRestartInterval
+	if ar.MinInterval != nil {
+		interval = ar.MinInterval.Duration
+	}
+	last := cr.Status.AtProvider.LastRestartTime
+	return last == nil || now.Sub(last.Time) >= interval
+}
+
+// setClusterCondition marks the supplied Connector as not ready if the
+// supplied error indicates its ProviderConfig points at the wrong cluster, or
+// that the cluster is unavailable.
+func setClusterCondition(cr *v1alpha1.Connector, err error) {
+	switch {
+	case kafkaconnect.IsClusterMismatch(err):
+		cr.SetConditions(v1alpha1.ClusterMismatch(err))
+	case kafkaconnect.IsUnavailable(err):
+		cr.SetConditions(v1alpha1.ClusterUnavailable(err))
+	}
+}
+
+func (c *external) Create(ctx context.Context, mg resource.Managed) (_ managed.ExternalCreation, err error) {
 	cr, ok := mg.(*v1alpha1.Connector)
 	if !ok {
 		return managed.ExternalCreation{}, errors.New(errNotConnector)
 	}
 
-	fmt.Printf("Creating: %+v", cr)
+	ctx, span := c.startSpan(ctx, "Create", cr)
+	defer func() { tracing.End(span, err) }()
+	defer func() { setClusterCondition(cr, err) }()
 
-	return managed.ExternalCreation{
-		// Optionally return any details that may be required to connect to the
-		// external resource. These will be stored as the connection secret.
-		ConnectionDetails: managed.ConnectionDetails{},
-	}, nil
+	cr.SetConditions(xpv1.Creating())
+
+	config, err := desiredConfig(cr, c.secrets)
+	if err != nil {
+		return managed.ExternalCreation{}, err
+	}
+
+	name := connectorName(cr)
+	c.invalidate(name)
+	_, err = c.service.CreateConnector(ctx, kafkaconnect.ConnectorConfig{
+		Name:   name,
+		Config: config,
+	})
+	if err != nil {
+		metrics.RecordFailure(name, c.providerConfig, metrics.OperationCreate)
+		return managed.ExternalCreation{}, errors.Wrap(err, errCreateConnector)
+	}
+
+	return managed.ExternalCreation{}, nil
 }
 
-func (c *external) Update(ctx context.Context, mg resource.Managed) (managed.ExternalUpdate, error) {
+func (c *external) Update(ctx context.Context, mg resource.Managed) (_ managed.ExternalUpdate, err error) {
 	cr, ok := mg.(*v1alpha1.Connector)
 	if !ok {
 		return managed.ExternalUpdate{}, errors.New(errNotConnector)
 	}
 
-	fmt.Printf("Updating: %+v", cr)
+	ctx, span := c.startSpan(ctx, "Update", cr)
+	defer func() { tracing.End(span, err) }()
+	defer func() { setClusterCondition(cr, err) }()
 
-	return managed.ExternalUpdate{
-		// Optionally return any details that may be required to connect to the
-		// external resource. These will be stored as the connection secret.
-		ConnectionDetails: managed.ConnectionDetails{},
-	}, nil
+	config, err := desiredConfig(cr, c.secrets)
+	if err != nil {
+		return managed.ExternalUpdate{}, err
+	}
+
+	name := connectorName(cr)
+	c.invalidate(name)
+
+	// Updating the config restarts the tasks of the connector, so a failed
+	// connector is only restarted if it is still failed afterwards.
+	if !c.configUpToDate || c.failed == nil {
+		if _, err := c.service.UpdateConnector(ctx, name, config); err != nil {
+			metrics.RecordFailure(name, c.providerConfig, metrics.OperationUpdate)
+			return managed.ExternalUpdate{}, errors.Wrap(err, errUpdateConnector)
+		}
+		return managed.ExternalUpdate{}, nil
+	}
+
+	if err := c.restart(ctx, name, c.failed); err != nil {
+		return managed.ExternalUpdate{}, errors.Wrap(err, errRestartConnector)
+	}
+	metrics.RecordRestart(name, c.providerConfig)
+	cr.Status.AtProvider.LastRestartTime = &metav1.Time{Time: time.Now()}
+
+	return managed.ExternalUpdate{}, nil
 }
 
-func (c *external) Delete(ctx context.Context, mg resource.Managed) (managed.ExternalDelete, error) {
+// restart the failed instances of the named connector and its tasks.
+func (c *external) restart(ctx context.Context, name string, status *kafkaconnect.ConnectorStatus) error {
+	err := c.service.RestartConnector(ctx, name, true, true)
+	if !kafkaconnect.IsUnsupported(err) {
+		return err
+	}
+
+	// Workers before Kafka 3.0 restart the connector and each task
+	// separately.
+	if status.Connector.State == stateFailed {
+		if err := c.service.RestartConnector(ctx, name, false, false); err != nil {
+			return err
+		}
+	}
+	for _, t := range status.Tasks {
+		if t.State != stateFailed {
+			continue
+		}
+		if err := c.service.RestartTask(ctx, name, t.ID); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (c *external) Delete(ctx context.Context, mg resource.Managed) (_ managed.ExternalDelete, err error) {
 	cr, ok := mg.(*v1alpha1.Connector)
 	if !ok {
 		return managed.ExternalDelete{}, errors.New(errNotConnector)
 	}
 
-	fmt.Printf("Deleting: %+v", cr)
+	ctx, span := c.startSpan(ctx, "Delete", cr)
+	defer func() { tracing.End(span, err) }()
+	defer func() { setClusterCondition(cr, err) }()
+
+	cr.SetConditions(xpv1.Deleting())
+
+	name := connectorName(cr)
+	c.invalidate(name)
+	if err := c.service.DeleteConnector(ctx, name); err != nil && !kafkaconnect.IsNotFound(err) {
+		return managed.ExternalDelete{}, errors.Wrap(err, errDeleteConnector)
+	}
+	metrics.ForgetConnector(name, c.providerConfig)
 
 	return managed.ExternalDelete{}, nil
 }
 
 func (c *external) Disconnect(ctx context.Context) error {
+	if c.release != nil {
+		c.release()
+	}
 	return nil
 }
+
+// observe returns the info and status of the named connector, preferring the
+// cache of the shared status poller.
+func (c *external) observe(ctx context.Context, name string) (*kafkaconnect.ConnectorInfo, *kafkaconnect.ConnectorStatus, error) {
+	if c.cache != nil {
+		if ec, ok := c.cache.Get(name); ok {
+			return ec.Info, ec.Status, nil
+		}
+	}
+
+	info, err := c.service.GetConnector(ctx, name)
+	if kafkaconnect.IsNotFound(err) {
+		return nil, nil, err
+	}
+	if err != nil {
+		return nil, nil, errors.Wrap(err, errGetConnector)
+	}
+
+	status, err := c.service.GetConnectorStatus(ctx, name)
+	if err != nil {
+		return nil, nil, errors.Wrap(err, errGetConnectorStatus)
+	}
+	return info, status, nil
+}
+
+func (c *external) invalidate(name string) {
+	if c.cache != nil {
+		c.cache.Invalidate(name)
+	}
+}
+
+func (c *external) startSpan(ctx context.Context, name string, cr *v1alpha1.Connector) (context.Context, trace.Span) {
+	ctx, span := startSpan(ctx, name, cr)
+	span.SetAttributes(tracing.AttributeProviderConfig.String(c.providerConfig))
+	return ctx, span
+}
+
+func (c *external) recordState(name string, status *kafkaconnect.ConnectorStatus) {
+	tasks := make([]metrics.Task, 0, len(status.Tasks))
+	for _, t := range status.Tasks {
+		tasks = append(tasks, metrics.Task{ID: t.ID, Worker: t.WorkerID, State: t.State})
+	}
+	metrics.SetConnectorState(name, c.providerConfig, status.Connector.WorkerID, status.Connector.State, tasks)
+}
+
+func startSpan(ctx context.Context, name string, cr *v1alpha1.Connector) (context.Context, trace.Span) {
+	return tracing.Start(ctx, name, trace.WithAttributes(
+		tracing.AttributeConnector.String(connectorName(cr)),
+		tracing.AttributeResource.String(cr.GetName()),
+	))
+}
+
+// connectorName returns the name of the connector in Kafka Connect. It
+// defaults to the external name of the managed resource.
+func connectorName(cr *v1alpha1.Connector) string {
+	if cr.Spec.ForProvider.Name != "" {
+		return cr.Spec.ForProvider.Name
+	}
+	return meta.GetExternalName(cr)
+}
+
+func generateObservation(status *kafkaconnect.ConnectorStatus) v1alpha1.ConnectorObservation {
+	o := v1alpha1.ConnectorObservation{
+		State:    status.Connector.State,
+		WorkerID: status.Connector.WorkerID,
+	}
+	for _, t := range status.Tasks {
+		o.Tasks = append(o.Tasks, v1alpha1.TaskStatus{
+			ID:       t.ID,
+			State:    t.State,
+			WorkerID: t.WorkerID,
+			Trace:    t.Trace,
+		})
+	}
+	return o
+}
@@ -18,13 +18,33 @@ package connector
 
 import (
 	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/google/go-cmp/cmp"
+	"github.com/pkg/errors"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+	corev1 "k8s.io/api/core/v1"
+	extv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
 	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
 	"github.com/crossplane/crossplane-runtime/pkg/resource"
 	"github.com/crossplane/crossplane-runtime/pkg/test"
+
+	"github.com/crossplane/provider-kafkaconnect/apis/kafkaconnect/v1alpha1"
+	apisv1alpha1 "github.com/crossplane/provider-kafkaconnect/apis/v1alpha1"
+	"github.com/crossplane/provider-kafkaconnect/internal/clients/kafkaconnect"
+	"github.com/crossplane/provider-kafkaconnect/internal/clients/kafkaconnect/fake"
+	"github.com/crossplane/provider-kafkaconnect/internal/tracing"
 )
 
 // Unlike many Kubernetes projects Crossplane does not use third party testing
@@ -35,11 +55,72 @@ import (
 // https://github.com/golang/go/wiki/TestComments
 // https://github.com/crossplane/crossplane/blob/master/CONTRIBUTING.md#contributing-code
 
-func TestObserve(t *testing.T) {
-	type fields struct {
-		service interface{}
+const class = "FileStreamSource"
+
+type connectorModifier func(cr *v1alpha1.Connector)
+
+func withConfig(k string, v any) connectorModifier {
+	return func(cr *v1alpha1.Connector) { cr.Spec.ForProvider.Config[k] = jsonValue(v) }
+}
+
+// jsonValue returns the supplied value as a config value.
+func jsonValue(v any) extv1.JSON {
+	raw, err := json.Marshal(v)
+	if err != nil {
+		panic(err)
 	}
+	return extv1.JSON{Raw: raw}
+}
+
+func newConnector(m ...connectorModifier) *v1alpha1.Connector {
+	cr := &v1alpha1.Connector{Spec: v1alpha1.ConnectorSpec{ForProvider: v1alpha1.ConnectorParameters{
+		Name:           "a",
+		ConnectorClass: class,
+		TasksMax:       1,
+		Config:         map[string]extv1.JSON{"file": jsonValue("/tmp/a")},
+	}}}
+	for _, fn := range m {
+		fn(cr)
+	}
+	return cr
+}
 
+// rendered returns the config of a connector as Kafka Connect reports it.
+func rendered(m ...connectorModifier) map[string]string {
+	cfg, err := desiredConfig(newConnector(m...), nil)
+	if err != nil {
+		panic(err)
+	}
+	return cfg
+}
+
+// existing returns a running connector as the fake server models it.
+func existing(m ...connectorModifier) fake.Connector {
+	return fake.Connector{Name: "a", Type: "source", Config: rendered(m...), State: fake.StateRunning}
+}
+
+// fields configure the fake Kafka Connect server a test runs against.
+type fields struct {
+	server []fake.Option
+	faults []fake.Fault
+}
+
+func newExternal(t *testing.T, f fields) (*external, *fake.Server) {
+	t.Helper()
+	srv := fake.NewServer(append([]fake.Option{fake.WithPlugins(fake.Plugin{Class: class, Type: "source"})}, f.server...)...)
+	t.Cleanup(srv.Close)
+	for _, fl := range f.faults {
+		srv.InjectFault(fl)
+	}
+	svc := kafkaconnect.NewClient(srv.URL, kafkaconnect.WithRetryPolicy(kafkaconnect.RetryPolicy{MaxAttempts: 1}))
+	return &external{service: svc, providerConfig: "test"}, srv
+}
+
+func apiError(op string, code int, msg string) error {
+	return fmt.Errorf("failed to %s: %w", op, &kafkaconnect.APIError{StatusCode: code, Message: msg})
+}
+
+func TestObserve(t *testing.T) {
 	type args struct {
 		ctx context.Context
 		mg  resource.Managed
@@ -56,12 +137,39 @@ func TestObserve(t *testing.T) {
 		args   args
 		want   want
 	}{
-		// TODO: Add test cases.
+		"NotConnector": {
+			reason: "An error should be returned if the managed resource is not a Connector.",
+			args:   args{ctx: context.Background(), mg: &v1alpha1.ConnectorPlugin{}},
+			want:   want{err: errors.New(errNotConnector)},
+		},
+		"NotFound": {
+			reason: "A connector that does not exist should be reported as such.",
+			args:   args{ctx: context.Background(), mg: newConnector()},
+			want:   want{o: managed.ExternalObservation{ResourceExists: false}},
+		},
+		"UpToDate": {
+			reason: "A connector with the desired config should be reported as up to date.",
+			fields: fields{server: []fake.Option{fake.WithConnectors(existing())}},
+			args:   args{ctx: context.Background(), mg: newConnector()},
+			want:   want{o: managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: true}},
+		},
+		"ConfigDrift": {
+			reason: "A connector whose config differs from the desired config should be reported as outdated.",
+			fields: fields{server: []fake.Option{fake.WithConnectors(existing(withConfig("file", "/tmp/b")))}},
+			args:   args{ctx: context.Background(), mg: newConnector()},
+			want:   want{o: managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: false}},
+		},
+		"GetFailed": {
+			reason: "Errors getting the connector should be returned.",
+			fields: fields{faults: []fake.Fault{{Status: http.StatusInternalServerError, Message: "boom"}}},
+			args:   args{ctx: context.Background(), mg: newConnector()},
+			want:   want{err: errors.Wrap(apiError("get connector", http.StatusInternalServerError, "boom"), errGetConnector)},
+		},
 	}
 
 	for name, tc := range cases {
 		t.Run(name, func(t *testing.T) {
-			e := external{service: tc.fields.service}
+			e, _ := newExternal(t, tc.fields)
 			got, err := e.Observe(tc.args.ctx, tc.args.mg)
 			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
 				t.Errorf("\n%s\ne.Observe(...): -want error, +got error:\n%s\n", tc.reason, diff)
@@ -72,3 +180,389 @@ func TestObserve(t *testing.T) {
 		})
 	}
 }
+
+func TestCreate(t *testing.T) {
+	type want struct {
+		config map[string]string
+		err    error
+	}
+
+	cases := map[string]struct {
+		reason string
+		fields fields
+		mg     *v1alpha1.Connector
+		want   want
+	}{
+		"Created": {
+			reason: "The connector should be created with the desired config.",
+			mg:     newConnector(),
+			want:   want{config: rendered()},
+		},
+		"UnknownClass": {
+			reason: "Errors creating the connector should be returned.",
+			mg:     newConnector(func(cr *v1alpha1.Connector) { cr.Spec.ForProvider.ConnectorClass = "Unknown" }),
+			want: want{err: errors.Wrap(apiError("create connector", http.StatusBadRequest,
+				fmt.Sprint("Connector config ", map[string]string{"connector.class": "Unknown", "file": "/tmp/a", "name": "a", "tasks.max": "1"}, " contains no connector type")), errCreateConnector)},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			e, srv := newExternal(t, tc.fields)
+			_, err := e.Create(context.Background(), tc.mg)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\ne.Create(...): -want error, +got error:\n%s\n", tc.reason, diff)
+			}
+			got, _ := srv.Connector("a")
+			if diff := cmp.Diff(tc.want.config, got.Config); diff != "" {
+				t.Errorf("\n%s\ne.Create(...): -want config, +got config:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestUpdate(t *testing.T) {
+	type want struct {
+		config map[string]string
+		err    error
+	}
+
+	cases := map[string]struct {
+		reason string
+		fields fields
+		mg     *v1alpha1.Connector
+		want   want
+	}{
+		"Updated": {
+			reason: "The connector's config should be replaced with the desired config.",
+			fields: fields{server: []fake.Option{fake.WithConnectors(existing(withConfig("file", "/tmp/b"), withConfig("removed", "true")))}},
+			mg:     newConnector(),
+			want:   want{config: rendered()},
+		},
+		"Rebalancing": {
+			reason: "Errors updating the connector should be returned.",
+			fields: fields{
+				server: []fake.Option{fake.WithConnectors(existing(withConfig("file", "/tmp/b")))},
+				faults: []fake.Fault{{Method: http.MethodPut, Status: http.StatusConflict, Message: fake.MessageRebalance}},
+			},
+			mg: newConnector(),
+			want: want{
+				config: rendered(withConfig("file", "/tmp/b")),
+				err:    errors.Wrap(apiError("update connector", http.StatusConflict, fake.MessageRebalance), errUpdateConnector),
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			e, srv := newExternal(t, tc.fields)
+			_, err := e.Update(context.Background(), tc.mg)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\ne.Update(...): -want error, +got error:\n%s\n", tc.reason, diff)
+			}
+			got, _ := srv.Connector("a")
+			if diff := cmp.Diff(tc.want.config, got.Config); diff != "" {
+				t.Errorf("\n%s\ne.Update(...): -want config, +got config:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestAutoRestart(t *testing.T) {
+	failed := func(c fake.Connector) fake.Connector {
+		c.Tasks = []fake.Task{{ID: 0, State: fake.StateFailed}, {ID: 1, State: fake.StateRunning}}
+		return c
+	}
+	autoRestart := func(cr *v1alpha1.Connector) {
+		cr.Spec.ForProvider.AutoRestart = &v1alpha1.AutoRestart{Enabled: true, MinInterval: &metav1.Duration{Duration: time.Minute}}
+	}
+	restartedAt := func(at time.Time) connectorModifier {
+		return func(cr *v1alpha1.Connector) { cr.Status.AtProvider.LastRestartTime = &metav1.Time{Time: at} }
+	}
+
+	type want struct {
+		upToDate bool
+		restarts []int
+	}
+
+	cases := map[string]struct {
+		reason    string
+		server    []fake.Option
+		connector fake.Connector
+		mg        *v1alpha1.Connector
+		want      want
+	}{
+		"Restarted": {
+			reason:    "Failed tasks of a connector that restarts automatically should be restarted.",
+			connector: failed(existing(autoRestart)),
+			mg:        newConnector(autoRestart),
+			want:      want{restarts: []int{1, 0}},
+		},
+		"OldWorker": {
+			reason:    "Failed tasks should be restarted one by one on workers before Kafka 3.0.",
+			server:    []fake.Option{fake.WithVersion("2.8.2")},
+			connector: failed(existing(autoRestart)),
+			mg:        newConnector(autoRestart),
+			want:      want{restarts: []int{1, 0}},
+		},
+		"RecentlyRestarted": {
+			reason:    "A connector restarted within the minimum interval should not be restarted again.",
+			connector: failed(existing(autoRestart)),
+			mg:        newConnector(autoRestart, restartedAt(time.Now())),
+			want:      want{upToDate: true, restarts: []int{0, 0}},
+		},
+		"Disabled": {
+			reason:    "Connectors that do not restart automatically should not be restarted.",
+			connector: failed(existing()),
+			mg:        newConnector(),
+			want:      want{upToDate: true, restarts: []int{0, 0}},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			e, srv := newExternal(t, fields{server: append(tc.server, fake.WithConnectors(tc.connector))})
+			o, err := e.Observe(context.Background(), tc.mg)
+			if err != nil {
+				t.Fatalf("\n%s\ne.Observe(...): %v", tc.reason, err)
+			}
+			if diff := cmp.Diff(tc.want.upToDate, o.ResourceUpToDate); diff != "" {
+				t.Errorf("\n%s\ne.Observe(...): -want up to date, +got:\n%s\n", tc.reason, diff)
+			}
+			if !o.ResourceUpToDate {
+				if _, err := e.Update(context.Background(), tc.mg); err != nil {
+					t.Fatalf("\n%s\ne.Update(...): %v", tc.reason, err)
+				}
+				if tc.mg.Status.AtProvider.LastRestartTime == nil {
+					t.Errorf("\n%s\ne.Update(...): want the restart to be recorded", tc.reason)
+				}
+			}
+
+			got, _ := srv.Connector("a")
+			restarts := make([]int, 0, len(got.Tasks))
+			for _, task := range got.Tasks {
+				restarts = append(restarts, task.Restarts)
+			}
+			if diff := cmp.Diff(tc.want.restarts, restarts); diff != "" {
+				t.Errorf("\n%s\ne.Update(...): -want task restarts, +got:\n%s\n", tc.reason, diff)
+			}
+			for _, r := range srv.Requests() {
+				if r.Method == http.MethodPut {
+					t.Errorf("\n%s\ne.Update(...): want the config of a failed connector not to be updated", tc.reason)
+				}
+			}
+		})
+	}
+}
+
+func TestDelete(t *testing.T) {
+	cases := map[string]struct {
+		reason string
+		fields fields
+		want   error
+	}{
+		"Deleted": {
+			reason: "The connector should be deleted.",
+			fields: fields{server: []fake.Option{fake.WithConnectors(existing())}},
+		},
+		"NotFound": {
+			reason: "Deleting a connector that does not exist should succeed.",
+		},
+		"Unavailable": {
+			reason: "Errors deleting the connector should be returned.",
+			fields: fields{
+				server: []fake.Option{fake.WithConnectors(existing())},
+				faults: []fake.Fault{{Method: http.MethodDelete, Status: http.StatusServiceUnavailable, Message: "unavailable"}},
+			},
+			want: errors.Wrap(apiError("delete connector", http.StatusServiceUnavailable, "unavailable"), errDeleteConnector),
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			e, srv := newExternal(t, tc.fields)
+			_, err := e.Delete(context.Background(), newConnector())
+			if diff := cmp.Diff(tc.want, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\ne.Delete(...): -want error, +got error:\n%s\n", tc.reason, diff)
+			}
+			if _, exists := srv.Connector("a"); exists != (tc.want != nil) {
+				t.Errorf("\n%s\ne.Delete(...): want connector to exist %t, got %t", tc.reason, tc.want != nil, exists)
+			}
+		})
+	}
+}
+
+func TestResolveSecrets(t *testing.T) {
+	errBoom := errors.New("boom")
+	ref := xpv1.SecretKeySelector{SecretReference: xpv1.SecretReference{Namespace: "ns", Name: "registry"}, Key: "userinfo"}
+	withCreds := withConverters(&v1alpha1.Converters{
+		Value: &v1alpha1.Converter{Class: "io.confluent.connect.avro.AvroConverter", SchemaRegistryCredentialsSecretRef: &ref},
+	})
+
+	type want struct {
+		values secretValues
+		err    error
+	}
+
+	cases := map[string]struct {
+		reason string
+		kube   client.Client
+		cr     *v1alpha1.Connector
+		want   want
+	}{
+		"NoReferences": {
+			reason: "No secrets should be read if none are referenced.",
+			cr:     newConnector(),
+		},
+		"Resolved": {
+			reason: "The values of referenced secret keys should be returned.",
+			kube: &test.MockClient{MockGet: test.NewMockGetFn(nil, func(obj client.Object) error {
+				obj.(*corev1.Secret).Data = map[string][]byte{"userinfo": []byte("user:pass")}
+				return nil
+			})},
+			cr:   newConnector(withCreds),
+			want: want{values: secretValues{ref: "user:pass"}},
+		},
+		"NoKey": {
+			reason: "Referencing a missing secret key should return an error.",
+			kube:   &test.MockClient{MockGet: test.NewMockGetFn(nil)},
+			cr:     newConnector(withCreds),
+			want:   want{err: errors.Errorf(errFmtNoKey, "ns", "registry", "userinfo")},
+		},
+		"GetError": {
+			reason: "Errors getting a referenced secret should be returned.",
+			kube:   &test.MockClient{MockGet: test.NewMockGetFn(errBoom)},
+			cr:     newConnector(withCreds),
+			want:   want{err: errors.Wrapf(errBoom, errFmtGetSecret, "ns", "registry")},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			c := &connector{kube: tc.kube}
+			got, err := c.resolveSecrets(context.Background(), tc.cr)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\nc.resolveSecrets(...): -want error, +got error:\n%s\n", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.values, got); diff != "" {
+				t.Errorf("\n%s\nc.resolveSecrets(...): -want, +got:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestTracing(t *testing.T) {
+	gtp, gprop := otel.GetTracerProvider(), otel.GetTextMapPropagator()
+	defer func() {
+		otel.SetTracerProvider(gtp)
+		otel.SetTextMapPropagator(gprop)
+	}()
+
+	exp := tracetest.NewInMemoryExporter()
+	tp := tracing.Install(exp, tracing.Options{SampleRatio: 1})
+
+	srv := fake.NewServer(fake.WithPlugins(fake.Plugin{Class: class, Type: "source"}))
+	defer srv.Close()
+
+	c := &connector{
+		kube: &test.MockClient{MockGet: test.NewMockGetFn(nil, func(obj client.Object) error {
+			obj.(*apisv1alpha1.ProviderConfig).Spec = apisv1alpha1.ProviderConfigSpec{
+				KafkaConnectURL: srv.URL,
+				Credentials:     apisv1alpha1.ProviderCredentials{Source: xpv1.CredentialsSourceNone},
+			}
+			return nil
+		})},
+		usage:        resource.TrackerFn(func(_ context.Context, _ resource.Managed) error { return nil }),
+		newServiceFn: newKafkaConnectService,
+	}
+	cr := newConnector()
+	cr.SetProviderConfigReference(&xpv1.Reference{Name: "tracing"})
+
+	ctx := context.Background()
+	e, err := c.Connect(ctx, cr)
+	if err != nil {
+		t.Fatalf("c.Connect(...): %v", err)
+	}
+	if _, err := e.Observe(ctx, cr); err != nil {
+		t.Fatalf("e.Observe(...): %v", err)
+	}
+	if _, err := e.Create(ctx, cr); err != nil {
+		t.Fatalf("e.Create(...): %v", err)
+	}
+	if _, err := e.Update(ctx, cr); err != nil {
+		t.Fatalf("e.Update(...): %v", err)
+	}
+	if _, err := e.Delete(ctx, cr); err != nil {
+		t.Fatalf("e.Delete(...): %v", err)
+	}
+	if err := tp.ForceFlush(ctx); err != nil {
+		t.Fatalf("ForceFlush(...): %v", err)
+	}
+
+	spans := exp.GetSpans()
+	names := make(map[trace.SpanID]string, len(spans))
+	traced := map[string]bool{}
+	for _, s := range spans {
+		names[s.SpanContext.SpanID()] = s.Name
+		traced[s.Name] = true
+	}
+
+	// Every Kafka Connect request should be traced as a child of the
+	// operation that made it.
+	got := map[string]bool{}
+	for _, s := range spans {
+		if !strings.Contains(s.Name, " /") {
+			continue
+		}
+		parent := names[s.Parent.SpanID()]
+		if parent == "" {
+			t.Errorf("span %q has no parent operation span", s.Name)
+			continue
+		}
+		got[parent] = true
+	}
+	want := map[string]bool{"Observe": true, "Create": true, "Update": true, "Delete": true}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("operations with Kafka Connect request spans: -want, +got:\n%s", diff)
+	}
+
+	for _, name := range []string{"Connect", "Observe", "Create", "Update", "Delete"} {
+		if !traced[name] {
+			t.Errorf("want a %s span", name)
+		}
+	}
+}
+
+func TestURLOverrideClusterMismatch(t *testing.T) {
+	srv := fake.NewServer(fake.WithKafkaClusterID("other"), fake.WithPlugins(fake.Plugin{Class: class, Type: "source"}))
+	defer srv.Close()
+
+	c := &connector{
+		kube: &test.MockClient{MockGet: test.NewMockGetFn(nil, func(obj client.Object) error {
+			obj.(*apisv1alpha1.ProviderConfig).Spec = apisv1alpha1.ProviderConfigSpec{
+				KafkaConnectURL:          "http://workers.invalid",
+				Credentials:              apisv1alpha1.ProviderCredentials{Source: xpv1.CredentialsSourceNone},
+				ExpectedKafkaClusterID:   "cluster",
+				AllowedConnectorURLHosts: []string{"127.0.0.1"},
+			}
+			return nil
+		})},
+		usage:        resource.TrackerFn(func(_ context.Context, _ resource.Managed) error { return nil }),
+		newServiceFn: newKafkaConnectService,
+	}
+	cr := newConnector(func(cr *v1alpha1.Connector) { cr.Spec.ForProvider.KafkaConnectURL = srv.URL })
+	cr.SetProviderConfigReference(&xpv1.Reference{Name: "override"})
+
+	// A URL override must not escape the cluster the ProviderConfig expects.
+	ctx := context.Background()
+	e, err := c.Connect(ctx, cr)
+	if err != nil {
+		t.Fatalf("c.Connect(...): %v", err)
+	}
+	if _, err := e.Create(ctx, cr); !kafkaconnect.IsClusterMismatch(err) {
+		t.Errorf("e.Create(...): want cluster mismatch, got %v", err)
+	}
+	if _, ok := srv.Connector("a"); ok {
+		t.Errorf("srv.Connector(...): want connector not created on the mismatched cluster")
+	}
+}
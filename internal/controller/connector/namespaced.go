@@ -0,0 +1,315 @@
+/*
+Copyright 2025 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package connector
+
+import (
+	"context"
+	"slices"
+
+	"github.com/pkg/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/event"
+	"github.com/crossplane/crossplane-runtime/pkg/feature"
+	"github.com/crossplane/crossplane-runtime/pkg/meta"
+	"github.com/crossplane/crossplane-runtime/pkg/ratelimiter"
+	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
+	"github.com/crossplane/crossplane-runtime/pkg/statemetrics"
+
+	"github.com/crossplane/provider-kafkaconnect/apis/kafkaconnect/v1alpha1"
+	nskcv1alpha1 "github.com/crossplane/provider-kafkaconnect/apis/namespaced/kafkaconnect/v1alpha1"
+	nsv1alpha1 "github.com/crossplane/provider-kafkaconnect/apis/namespaced/v1alpha1"
+	apisv1alpha1 "github.com/crossplane/provider-kafkaconnect/apis/v1alpha1"
+	"github.com/crossplane/provider-kafkaconnect/internal/clients/kafkaconnect"
+	"github.com/crossplane/provider-kafkaconnect/internal/options"
+	"github.com/crossplane/provider-kafkaconnect/internal/tracing"
+)
+
+const (
+	errNotNamespacedConnector = "managed resource is not a namespaced Connector custom resource"
+	errNoPCRef                = "managed resource does not reference a ProviderConfig"
+	errApplyPCU               = "cannot apply ProviderConfigUsage"
+	errFmtPCKind              = "unknown ProviderConfig kind %q"
+	errFmtSecretNamespace     = "cannot reference Secret %s/%s in another namespace"
+	errFmtNotCreated          = "connector %q exists in Kafka Connect but was not created by this Connector"
+)
+
+// SetupNamespaced adds a controller that reconciles namespaced Connector
+// managed resources. Their status is always observed individually.
+func SetupNamespaced(mgr ctrl.Manager, o options.Options) error {
+	name := managed.ControllerName(nskcv1alpha1.ConnectorGroupKind)
+
+	opts := []managed.ReconcilerOption{
+		managed.WithExternalConnecter(&namespacedConnector{
+			connector: &connector{
+				kube:         mgr.GetClient(),
+				newServiceFn: newKafkaConnectService,
+				services:     newServiceCache()},
+			usage: &usageTracker{apply: resource.NewAPIPatchingApplicator(mgr.GetClient())}}),
+		managed.WithLogger(o.Logger.WithValues("controller", name)),
+		managed.WithPollInterval(o.PollInterval),
+		managed.WithRecorder(event.NewAPIRecorder(mgr.GetEventRecorderFor(name))),
+		managed.WithManagementPolicies(),
+	}
+
+	if o.Features.Enabled(feature.EnableAlphaChangeLogs) {
+		opts = append(opts, managed.WithChangeLogger(o.ChangeLogOptions.ChangeLogger))
+	}
+
+	if o.MetricOptions != nil {
+		opts = append(opts, managed.WithMetricRecorder(o.MetricOptions.MRMetrics))
+	}
+
+	if o.MetricOptions != nil && o.MetricOptions.MRStateMetrics != nil {
+		stateMetricsRecorder := statemetrics.NewMRStateRecorder(
+			mgr.GetClient(), o.Logger, o.MetricOptions.MRStateMetrics, &nskcv1alpha1.ConnectorList{}, o.MetricOptions.PollStateMetricInterval,
+		)
+		if err := mgr.Add(stateMetricsRecorder); err != nil {
+			return errors.Wrap(err, "cannot register MR state metrics recorder for kind namespaced v1alpha1.ConnectorList")
+		}
+	}
+
+	r := managed.NewReconciler(mgr, resource.ManagedKind(nskcv1alpha1.ConnectorGroupVersionKind), opts...)
+
+	return ctrl.NewControllerManagedBy(mgr).
+		Named(name).
+		WithOptions(o.ForControllerRuntime()).
+		WithEventFilter(resource.DesiredStateChanged()).
+		For(&nskcv1alpha1.Connector{}).
+		Complete(ratelimiter.NewReconciler(name, &syncedReconciler{
+			Reconciler: r,
+			kube:       mgr.GetClient(),
+			newObject:  func() resource.Managed { return &nskcv1alpha1.Connector{} },
+		}, o.GlobalRateLimiter))
+}
+
+// A namespacedConnector produces ExternalClients for namespaced Connectors.
+// It manages them through a cluster scoped view of the Connector, and a
+// ProviderConfig view of their ProviderConfig or ClusterProviderConfig.
+type namespacedConnector struct {
+	*connector
+	usage resource.Tracker
+}
+
+// Connect produces an ExternalClient for a namespaced Connector. A Connector
+// may only use a ProviderConfig and Secrets in its own namespace.
+func (c *namespacedConnector) Connect(ctx context.Context, mg resource.Managed) (_ managed.ExternalClient, err error) {
+	cr, ok := mg.(*nskcv1alpha1.Connector)
+	if !ok {
+		return nil, errors.New(errNotNamespacedConnector)
+	}
+
+	view := clusterScoped(cr)
+	defer func() { cr.Status = view.Status }()
+
+	ctx, span := startSpan(ctx, "Connect", view)
+	defer func() { tracing.End(span, err) }()
+
+	if err := c.usage.Track(ctx, mg); err != nil {
+		return nil, errors.Wrap(err, errTrackPCUsage)
+	}
+
+	pc, err := c.getProviderConfig(ctx, cr)
+	if err != nil {
+		return nil, errors.Wrap(err, errGetPC)
+	}
+
+	for _, ref := range secretRefs(cr.Spec.ForProvider) {
+		if ref.Namespace != cr.GetNamespace() {
+			return nil, errors.Errorf(errFmtSecretNamespace, ref.Namespace, ref.Name)
+		}
+	}
+
+	e, err := c.connect(ctx, span, view, pc)
+	if err != nil {
+		return nil, err
+	}
+	return &namespacedExternal{external: e}, nil
+}
+
+// getProviderConfig returns a view of the ProviderConfig or
+// ClusterProviderConfig of the supplied Connector.
+func (c *namespacedConnector) getProviderConfig(ctx context.Context, cr *nskcv1alpha1.Connector) (_ *apisv1alpha1.ProviderConfig, err error) {
+	ctx, span := tracing.Start(ctx, "GetProviderConfig")
+	defer func() { tracing.End(span, err) }()
+
+	ref := cr.Spec.ProviderConfigReference
+	if ref == nil {
+		return nil, errors.New(errNoPCRef)
+	}
+
+	switch ref.Kind {
+	case nsv1alpha1.ProviderConfigKind:
+		pc := &nsv1alpha1.ProviderConfig{}
+		if err := c.kube.Get(ctx, types.NamespacedName{Namespace: cr.GetNamespace(), Name: ref.Name}, pc); err != nil {
+			return nil, err
+		}
+		if err := kafkaconnect.CheckNamespace(pc); err != nil {
+			return nil, err
+		}
+		return kafkaconnect.ProviderConfigView(pc), nil
+	case nsv1alpha1.ClusterProviderConfigKind:
+		pc := &nsv1alpha1.ClusterProviderConfig{}
+		if err := c.kube.Get(ctx, types.NamespacedName{Name: ref.Name}, pc); err != nil {
+			return nil, err
+		}
+		return kafkaconnect.ProviderConfigView(pc), nil
+	default:
+		return nil, errors.Errorf(errFmtPCKind, ref.Kind)
+	}
+}
+
+// clusterScoped returns a cluster scoped view of the supplied namespaced
+// Connector. Changes to the status of the view must be copied back.
+//
+// The Kafka Connect cluster of a ClusterProviderConfig is shared by all
+// namespaces, so the name of the connector of a Connector using one is
+// qualified by its namespace, e.g. team.a. Namespaces cannot contain dots, so
+// a Connector cannot choose the name of a connector of another namespace.
+func clusterScoped(cr *nskcv1alpha1.Connector) *v1alpha1.Connector {
+	view := &v1alpha1.Connector{
+		ObjectMeta: *cr.ObjectMeta.DeepCopy(),
+		Spec: v1alpha1.ConnectorSpec{
+			ResourceSpec: xpv1.ResourceSpec{
+				ManagementPolicies: cr.Spec.ManagementPolicies,
+				DeletionPolicy:     cr.Spec.DeletionPolicy,
+			},
+			ForProvider: *cr.Spec.ForProvider.DeepCopy(),
+		},
+		Status: *cr.Status.DeepCopy(),
+	}
+	if ref := cr.Spec.ProviderConfigReference; ref != nil && ref.Kind == nsv1alpha1.ClusterProviderConfigKind {
+		view.Spec.ForProvider.Name = cr.GetNamespace() + "." + connectorName(view)
+	}
+	return view
+}
+
+// A usageTracker tracks that namespaced Connectors use their ProviderConfig
+// or ClusterProviderConfig, using a ProviderConfigUsage in their namespace.
+type usageTracker struct {
+	apply resource.Applicator
+}
+
+// Track that the supplied namespaced Connector uses its provider config.
+func (u *usageTracker) Track(ctx context.Context, mg resource.Managed) error {
+	cr, ok := mg.(*nskcv1alpha1.Connector)
+	if !ok {
+		return errors.New(errNotNamespacedConnector)
+	}
+	ref := cr.Spec.ProviderConfigReference
+	if ref == nil {
+		return errors.New(errNoPCRef)
+	}
+
+	gvk := nskcv1alpha1.ConnectorGroupVersionKind
+	pcu := &nsv1alpha1.ProviderConfigUsage{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: cr.GetNamespace(),
+			Name:      string(cr.GetUID()),
+			Labels: map[string]string{
+				xpv1.LabelKeyProviderName:             ref.Name,
+				nsv1alpha1.LabelKeyProviderConfigKind: ref.Kind,
+			},
+			OwnerReferences: []metav1.OwnerReference{meta.AsController(meta.TypedReferenceTo(cr, gvk))},
+		},
+		ProviderConfigReference: *ref,
+		ResourceReference: xpv1.TypedReference{
+			APIVersion: gvk.GroupVersion().String(),
+			Kind:       gvk.Kind,
+			Name:       cr.GetName(),
+		},
+	}
+
+	err := u.apply.Apply(ctx, pcu,
+		resource.MustBeControllableBy(cr.GetUID()),
+		resource.AllowUpdateIf(func(current, _ runtime.Object) bool {
+			cur, ok := current.(*nsv1alpha1.ProviderConfigUsage)
+			return ok && cur.ProviderConfigReference != pcu.ProviderConfigReference
+		}),
+	)
+	return errors.Wrap(resource.Ignore(resource.IsNotAllowed, err), errApplyPCU)
+}
+
+// A namespacedExternal manages the connector of a namespaced Connector through
+// its cluster scoped view.
+type namespacedExternal struct {
+	*external
+}
+
+// Observe the connector of a namespaced Connector. Connectors that exist but
+// were not created by a Connector whose management policies allow it to
+// create them are not adopted, since they may belong to another namespace; a
+// Connector being deleted releases them untouched. Connectors that may not
+// create their connector observe an existing one, like their policies ask.
+func (e *namespacedExternal) Observe(ctx context.Context, mg resource.Managed) (managed.ExternalObservation, error) {
+	cr, ok := mg.(*nskcv1alpha1.Connector)
+	if !ok {
+		return managed.ExternalObservation{}, errors.New(errNotNamespacedConnector)
+	}
+	view := clusterScoped(cr)
+	o, err := e.external.Observe(ctx, view)
+	if err != nil || !o.ResourceExists || !meta.GetExternalCreateSucceeded(cr).IsZero() || !mayCreate(cr) {
+		cr.Status = view.Status
+		return o, err
+	}
+	if meta.WasDeleted(cr) {
+		return managed.ExternalObservation{ResourceExists: false}, nil
+	}
+	return managed.ExternalObservation{}, errors.Errorf(errFmtNotCreated, connectorName(view))
+}
+
+// mayCreate returns true if the management policies of the supplied managed
+// resource allow it to create its external resource.
+func mayCreate(mg resource.Managed) bool {
+	p := mg.GetManagementPolicies()
+	return len(p) == 0 || slices.Contains(p, xpv1.ManagementActionAll) || slices.Contains(p, xpv1.ManagementActionCreate)
+}
+
+func (e *namespacedExternal) Create(ctx context.Context, mg resource.Managed) (managed.ExternalCreation, error) {
+	cr, ok := mg.(*nskcv1alpha1.Connector)
+	if !ok {
+		return managed.ExternalCreation{}, errors.New(errNotNamespacedConnector)
+	}
+	view := clusterScoped(cr)
+	defer func() { cr.Status = view.Status }()
+	return e.external.Create(ctx, view)
+}
+
+func (e *namespacedExternal) Update(ctx context.Context, mg resource.Managed) (managed.ExternalUpdate, error) {
+	cr, ok := mg.(*nskcv1alpha1.Connector)
+	if !ok {
+		return managed.ExternalUpdate{}, errors.New(errNotNamespacedConnector)
+	}
+	view := clusterScoped(cr)
+	defer func() { cr.Status = view.Status }()
+	return e.external.Update(ctx, view)
+}
+
+func (e *namespacedExternal) Delete(ctx context.Context, mg resource.Managed) (managed.ExternalDelete, error) {
+	cr, ok := mg.(*nskcv1alpha1.Connector)
+	if !ok {
+		return managed.ExternalDelete{}, errors.New(errNotNamespacedConnector)
+	}
+	view := clusterScoped(cr)
+	defer func() { cr.Status = view.Status }()
+	return e.external.Delete(ctx, view)
+}
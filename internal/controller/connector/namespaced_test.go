@@ -0,0 +1,388 @@
+/*
+Copyright 2025 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package connector
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/pkg/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/meta"
+	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+
+	"github.com/crossplane/provider-kafkaconnect/apis/kafkaconnect/v1alpha1"
+	nskcv1alpha1 "github.com/crossplane/provider-kafkaconnect/apis/namespaced/kafkaconnect/v1alpha1"
+	nsv1alpha1 "github.com/crossplane/provider-kafkaconnect/apis/namespaced/v1alpha1"
+	apisv1alpha1 "github.com/crossplane/provider-kafkaconnect/apis/v1alpha1"
+	"github.com/crossplane/provider-kafkaconnect/internal/clients/kafkaconnect"
+	"github.com/crossplane/provider-kafkaconnect/internal/clients/kafkaconnect/fake"
+)
+
+// newNamespacedConnector returns a namespaced Connector in namespace team
+// with the parameters of a cluster scoped one.
+func newNamespacedConnector(ref *nsv1alpha1.ProviderConfigReference, m ...connectorModifier) *nskcv1alpha1.Connector {
+	return &nskcv1alpha1.Connector{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "team", Name: "a", UID: "uid"},
+		Spec: nskcv1alpha1.ConnectorSpec{
+			ProviderConfigReference: ref,
+			ForProvider:             newConnector(m...).Spec.ForProvider,
+		},
+	}
+}
+
+// withProviderConfig returns a Get function that returns a ProviderConfig or
+// ClusterProviderConfig with the requested key and the supplied spec.
+func withProviderConfig(spec apisv1alpha1.ProviderConfigSpec) test.MockGetFn {
+	return func(_ context.Context, key client.ObjectKey, obj client.Object) error {
+		obj.SetNamespace(key.Namespace)
+		obj.SetName(key.Name)
+		switch pc := obj.(type) {
+		case *nsv1alpha1.ProviderConfig:
+			pc.Spec = spec
+		case *nsv1alpha1.ClusterProviderConfig:
+			pc.Spec = spec
+		}
+		return nil
+	}
+}
+
+func secretCredentials(namespace string) apisv1alpha1.ProviderConfigSpec {
+	return apisv1alpha1.ProviderConfigSpec{
+		KafkaConnectURL: "http://connect:8083",
+		Credentials: apisv1alpha1.ProviderCredentials{
+			Source: xpv1.CredentialsSourceSecret,
+			CommonCredentialSelectors: xpv1.CommonCredentialSelectors{SecretRef: &xpv1.SecretKeySelector{
+				SecretReference: xpv1.SecretReference{Namespace: namespace, Name: "creds"},
+				Key:             "credentials",
+			}},
+		},
+	}
+}
+
+func TestNamespacedGetProviderConfig(t *testing.T) {
+	errBoom := errors.New("boom")
+	pc := &nsv1alpha1.ProviderConfigReference{Kind: nsv1alpha1.ProviderConfigKind, Name: "pc"}
+	cpc := &nsv1alpha1.ProviderConfigReference{Kind: nsv1alpha1.ClusterProviderConfigKind, Name: "pc"}
+
+	type want struct {
+		name string
+		err  error
+	}
+
+	cases := map[string]struct {
+		reason string
+		kube   client.Client
+		ref    *nsv1alpha1.ProviderConfigReference
+		want   want
+	}{
+		"NoReference": {
+			reason: "An error should be returned if the Connector references no provider config.",
+			want:   want{err: errors.New(errNoPCRef)},
+		},
+		"UnknownKind": {
+			reason: "An error should be returned if the Connector references an unknown kind of provider config.",
+			ref:    &nsv1alpha1.ProviderConfigReference{Kind: "Secret", Name: "pc"},
+			want:   want{err: errors.Errorf(errFmtPCKind, "Secret")},
+		},
+		"ProviderConfig": {
+			reason: "A ProviderConfig in the namespace of the Connector should be named after its namespace.",
+			kube:   &test.MockClient{MockGet: withProviderConfig(secretCredentials("team"))},
+			ref:    pc,
+			want:   want{name: "team/pc"},
+		},
+		"ClusterProviderConfig": {
+			reason: "A ClusterProviderConfig should be named after its kind, and may use Secrets in any namespace.",
+			kube:   &test.MockClient{MockGet: withProviderConfig(secretCredentials("crossplane-system"))},
+			ref:    cpc,
+			want:   want{name: "ClusterProviderConfig/pc"},
+		},
+		"GetError": {
+			reason: "Errors getting the provider config should be returned.",
+			kube:   &test.MockClient{MockGet: test.NewMockGetFn(errBoom)},
+			ref:    pc,
+			want:   want{err: errBoom},
+		},
+		"SecretInOtherNamespace": {
+			reason: "A ProviderConfig should not use credentials from another namespace.",
+			kube:   &test.MockClient{MockGet: withProviderConfig(secretCredentials("crossplane-system"))},
+			ref:    pc,
+			want:   want{err: fmt.Errorf("ProviderConfig team/pc cannot use credentials from Secret crossplane-system/creds in another namespace")},
+		},
+		"ServiceInOtherNamespace": {
+			reason: "A ProviderConfig should not use workers behind a Service in another namespace.",
+			kube: &test.MockClient{MockGet: withProviderConfig(apisv1alpha1.ProviderConfigSpec{
+				Credentials: apisv1alpha1.ProviderCredentials{Source: xpv1.CredentialsSourceNone},
+				ServiceRef:  &apisv1alpha1.ServiceReference{Namespace: "kafka", Name: "connect"},
+			})},
+			ref:  pc,
+			want: want{err: fmt.Errorf("ProviderConfig team/pc cannot use Service kafka/connect in another namespace")},
+		},
+		"EnvironmentCredentials": {
+			reason: "A ProviderConfig should not use credentials from the environment of the provider.",
+			kube: &test.MockClient{MockGet: withProviderConfig(apisv1alpha1.ProviderConfigSpec{
+				KafkaConnectURL: "http://connect:8083",
+				Credentials:     apisv1alpha1.ProviderCredentials{Source: xpv1.CredentialsSourceEnvironment},
+			})},
+			ref:  pc,
+			want: want{err: fmt.Errorf("ProviderConfig team/pc cannot use Environment credentials")},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			c := &namespacedConnector{connector: &connector{kube: tc.kube}}
+			pc, err := c.getProviderConfig(context.Background(), newNamespacedConnector(tc.ref))
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\nc.getProviderConfig(...): -want error, +got error:\n%s\n", tc.reason, diff)
+			}
+			var got string
+			if pc != nil {
+				got = pc.GetName()
+			}
+			if diff := cmp.Diff(tc.want.name, got); diff != "" {
+				t.Errorf("\n%s\nc.getProviderConfig(...): -want name, +got name:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestNamespacedConnect(t *testing.T) {
+	errBoom := errors.New("boom")
+	ref := &nsv1alpha1.ProviderConfigReference{Kind: nsv1alpha1.ProviderConfigKind, Name: "pc"}
+	spec := apisv1alpha1.ProviderConfigSpec{
+		KafkaConnectURL: "http://connect:8083",
+		Credentials:     apisv1alpha1.ProviderCredentials{Source: xpv1.CredentialsSourceNone},
+	}
+	withCreds := withConverters(&v1alpha1.Converters{Value: &v1alpha1.Converter{
+		Class: "io.confluent.connect.avro.AvroConverter",
+		SchemaRegistryCredentialsSecretRef: &xpv1.SecretKeySelector{
+			SecretReference: xpv1.SecretReference{Namespace: "other", Name: "registry"},
+			Key:             "userinfo",
+		},
+	}})
+
+	type want struct {
+		providerConfig string
+		err            error
+	}
+
+	cases := map[string]struct {
+		reason string
+		usage  resource.Tracker
+		mg     resource.Managed
+		want   want
+	}{
+		"NotNamespacedConnector": {
+			reason: "An error should be returned if the managed resource is not a namespaced Connector.",
+			mg:     newConnector(),
+			want:   want{err: errors.New(errNotNamespacedConnector)},
+		},
+		"TrackError": {
+			reason: "Errors tracking the usage of the provider config should be returned.",
+			usage:  resource.TrackerFn(func(_ context.Context, _ resource.Managed) error { return errBoom }),
+			mg:     newNamespacedConnector(ref),
+			want:   want{err: errors.Wrap(errBoom, errTrackPCUsage)},
+		},
+		"SecretInOtherNamespace": {
+			reason: "A Connector should not reference Secrets in another namespace.",
+			usage:  resource.TrackerFn(func(_ context.Context, _ resource.Managed) error { return nil }),
+			mg:     newNamespacedConnector(ref, withCreds),
+			want:   want{err: errors.Errorf(errFmtSecretNamespace, "other", "registry")},
+		},
+		"Connected": {
+			reason: "A Connector should be managed through the view of its provider config.",
+			usage:  resource.TrackerFn(func(_ context.Context, _ resource.Managed) error { return nil }),
+			mg:     newNamespacedConnector(ref),
+			want:   want{providerConfig: "team/pc"},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			c := &namespacedConnector{
+				connector: &connector{
+					kube: &test.MockClient{MockGet: withProviderConfig(spec)},
+					newServiceFn: func(_ *apisv1alpha1.ProviderConfig, _ []byte, _ ...kafkaconnect.ClientOption) (Service, error) {
+						return kafkaconnect.NewClient("http://connect:8083"), nil
+					},
+				},
+				usage: tc.usage,
+			}
+			got, err := c.Connect(context.Background(), tc.mg)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\nc.Connect(...): -want error, +got error:\n%s\n", tc.reason, diff)
+			}
+			var pc string
+			if e, ok := got.(*namespacedExternal); ok {
+				pc = e.providerConfig
+			}
+			if diff := cmp.Diff(tc.want.providerConfig, pc); diff != "" {
+				t.Errorf("\n%s\nc.Connect(...): -want ProviderConfig, +got ProviderConfig:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestNamespacedObserve(t *testing.T) {
+	created := func(cr *nskcv1alpha1.Connector) { meta.SetExternalCreateSucceeded(cr, time.Now()) }
+	deleted := func(cr *nskcv1alpha1.Connector) { cr.SetDeletionTimestamp(&metav1.Time{Time: time.Now()}) }
+	observeOnly := func(cr *nskcv1alpha1.Connector) {
+		cr.SetManagementPolicies(xpv1.ManagementPolicies{xpv1.ManagementActionObserve})
+	}
+	cpc := &nsv1alpha1.ProviderConfigReference{Kind: nsv1alpha1.ClusterProviderConfigKind, Name: "shared"}
+
+	// The connector of Connector team/a using a ClusterProviderConfig.
+	qualified := existing()
+	qualified.Name = "team.a"
+	qualified.Config["name"] = "team.a"
+
+	type want struct {
+		o     managed.ExternalObservation
+		err   error
+		state string
+	}
+
+	cases := map[string]struct {
+		reason    string
+		ref       *nsv1alpha1.ProviderConfigReference
+		modifiers []func(*nskcv1alpha1.Connector)
+		want      want
+	}{
+		"Created": {
+			reason:    "The status observed through the cluster scoped view should be copied to the namespaced Connector.",
+			modifiers: []func(*nskcv1alpha1.Connector){created},
+			want: want{
+				o:     managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: true},
+				state: fake.StateRunning,
+			},
+		},
+		"NotCreated": {
+			reason: "A connector the Connector did not create should not be adopted, nor its status copied.",
+			want: want{
+				err: errors.Errorf(errFmtNotCreated, "a"),
+			},
+		},
+		"DeletedNotCreated": {
+			reason:    "Deleting a Connector should not delete a connector it did not create.",
+			modifiers: []func(*nskcv1alpha1.Connector){deleted},
+			want: want{
+				o: managed.ExternalObservation{ResourceExists: false},
+			},
+		},
+		"ObserveOnly": {
+			reason:    "A Connector whose management policies only observe should observe an existing connector it did not create.",
+			modifiers: []func(*nskcv1alpha1.Connector){observeOnly},
+			want: want{
+				o:     managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: true},
+				state: fake.StateRunning,
+			},
+		},
+		"ObserveAndCreate": {
+			reason: "A Connector whose management policies allow it to create its connector should not adopt one it did not create.",
+			modifiers: []func(*nskcv1alpha1.Connector){func(cr *nskcv1alpha1.Connector) {
+				cr.SetManagementPolicies(xpv1.ManagementPolicies{xpv1.ManagementActionObserve, xpv1.ManagementActionCreate})
+			}},
+			want: want{
+				err: errors.Errorf(errFmtNotCreated, "a"),
+			},
+		},
+		"ClusterProviderConfig": {
+			reason:    "The connector of a Connector using a ClusterProviderConfig should be named after its namespace.",
+			ref:       cpc,
+			modifiers: []func(*nskcv1alpha1.Connector){created},
+			want: want{
+				o:     managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: true},
+				state: fake.StateRunning,
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			e, _ := newExternal(t, fields{server: []fake.Option{fake.WithConnectors(existing(), qualified)}})
+			cr := newNamespacedConnector(tc.ref)
+			for _, fn := range tc.modifiers {
+				fn(cr)
+			}
+
+			got, err := (&namespacedExternal{external: e}).Observe(context.Background(), cr)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\ne.Observe(...): -want error, +got error:\n%s\n", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.o, got); diff != "" {
+				t.Errorf("\n%s\ne.Observe(...): -want, +got:\n%s\n", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.state, cr.Status.AtProvider.State); diff != "" {
+				t.Errorf("\n%s\ne.Observe(...): -want state, +got state:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestClusterScoped(t *testing.T) {
+	cases := map[string]struct {
+		reason string
+		ref    *nsv1alpha1.ProviderConfigReference
+		name   string
+		want   string
+	}{
+		"ProviderConfig": {
+			reason: "Connectors of a namespaced ProviderConfig should keep their name.",
+			ref:    &nsv1alpha1.ProviderConfigReference{Kind: nsv1alpha1.ProviderConfigKind, Name: "pc"},
+			name:   "a",
+			want:   "a",
+		},
+		"ClusterProviderConfig": {
+			reason: "Connectors of a ClusterProviderConfig should be qualified by their namespace.",
+			ref:    &nsv1alpha1.ProviderConfigReference{Kind: nsv1alpha1.ClusterProviderConfigKind, Name: "shared"},
+			name:   "a",
+			want:   "team.a",
+		},
+		"OtherNamespace": {
+			reason: "Connectors should not be able to choose the name of a connector of another namespace.",
+			ref:    &nsv1alpha1.ProviderConfigReference{Kind: nsv1alpha1.ClusterProviderConfigKind, Name: "shared"},
+			name:   "other.a",
+			want:   "team.other.a",
+		},
+		"ExternalName": {
+			reason: "The external name should be qualified if no name is set.",
+			ref:    &nsv1alpha1.ProviderConfigReference{Kind: nsv1alpha1.ClusterProviderConfigKind, Name: "shared"},
+			want:   "team.external",
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			cr := newNamespacedConnector(tc.ref)
+			cr.Spec.ForProvider.Name = tc.name
+			meta.SetExternalName(cr, "external")
+
+			if diff := cmp.Diff(tc.want, connectorName(clusterScoped(cr))); diff != "" {
+				t.Errorf("\n%s\nclusterScoped(...): -want name, +got name:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,283 @@
+/*
+Copyright 2025 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package connector
+
+import (
+	"context"
+	"reflect"
+	"sync"
+	"time"
+
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/source"
+
+	"github.com/crossplane/crossplane-runtime/pkg/logging"
+
+	"github.com/crossplane/provider-kafkaconnect/apis/kafkaconnect/v1alpha1"
+	"github.com/crossplane/provider-kafkaconnect/internal/clients/kafkaconnect"
+)
+
+const (
+	// A cluster poller stops once no Connector used it for this many
+	// intervals. It is restarted by the next Connect.
+	pollerIdleIntervals = 10
+
+	// Snapshots older than this many intervals are not used by Observe, e.g.
+	// because the last polls failed.
+	pollerStaleIntervals = 2
+)
+
+// A statusPoller runs one poller per ProviderConfig. Each lists all
+// connectors of its Kafka Connect cluster with their info and status once per
+// interval, caches the result for Observe, and enqueues the Connectors whose
+// connector changed since the previous poll.
+type statusPoller struct {
+	kube     client.Client
+	log      logging.Logger
+	interval time.Duration
+	events   chan event.GenericEvent
+
+	mu       sync.Mutex
+	ctx      context.Context
+	clusters map[string]*clusterPoller
+}
+
+func newStatusPoller(kube client.Client, log logging.Logger, interval time.Duration) *statusPoller {
+	return &statusPoller{
+		kube:     kube,
+		log:      log,
+		interval: interval,
+		events:   make(chan event.GenericEvent, 1024),
+		clusters: map[string]*clusterPoller{},
+	}
+}
+
+// Start allows cluster pollers to be started, and blocks until the supplied
+// context is done. It implements manager.Runnable.
+func (p *statusPoller) Start(ctx context.Context) error {
+	p.mu.Lock()
+	p.ctx = ctx
+	p.mu.Unlock()
+
+	<-ctx.Done()
+	return nil
+}
+
+// Source returns a source of events for Connectors whose connector changed.
+func (p *statusPoller) Source() source.Source {
+	return source.Channel(p.events, &handler.EnqueueRequestForObject{})
+}
+
+// ForProviderConfig returns the poller of the Kafka Connect cluster of the
+// supplied ProviderConfig, starting it if necessary. The supplied service
+// replaces the one previously used by the poller, so that it picks up
+// configuration and credential changes. It returns nil if the poller is not
+// running yet.
+func (p *statusPoller) ForProviderConfig(pc string, svc Service) *clusterPoller {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.ctx == nil || p.ctx.Err() != nil {
+		return nil
+	}
+
+	cp, ok := p.clusters[pc]
+	if !ok {
+		cp = &clusterPoller{
+			providerConfig: pc,
+			maxAge:         pollerStaleIntervals * p.interval,
+			invalidated:    map[string]time.Time{},
+		}
+		p.clusters[pc] = cp
+		go p.run(p.ctx, cp)
+	}
+	cp.use(svc)
+	return cp
+}
+
+func (p *statusPoller) run(ctx context.Context, cp *clusterPoller) {
+	log := p.log.WithValues("providerconfig", cp.providerConfig)
+	t := time.NewTicker(p.interval)
+	defer t.Stop()
+
+	for {
+		p.poll(ctx, log, cp)
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-t.C:
+		}
+
+		p.mu.Lock()
+		if cp.idleSince() > pollerIdleIntervals*p.interval {
+			delete(p.clusters, cp.providerConfig)
+			p.mu.Unlock()
+			log.Debug("Stopping idle connector status poller")
+			return
+		}
+		p.mu.Unlock()
+	}
+}
+
+func (p *statusPoller) poll(ctx context.Context, log logging.Logger, cp *clusterPoller) {
+	fetched := time.Now()
+	connectors, err := cp.service().ListConnectorsExpanded(ctx)
+	if kafkaconnect.IsUnsupported(err) {
+		// Observe reads each connector individually while the cache is empty.
+		if cp.markUnsupported() {
+			log.Info("Kafka Connect cannot list connectors with their status, observing connectors individually", "error", err)
+		}
+		return
+	}
+	if err != nil {
+		log.Debug("Cannot poll connector status", "error", err)
+		return
+	}
+
+	changed := cp.update(connectors, fetched)
+	if len(changed) == 0 {
+		return
+	}
+
+	l := &v1alpha1.ConnectorList{}
+	if err := p.kube.List(ctx, l); err != nil {
+		log.Debug("Cannot list Connectors", "error", err)
+		return
+	}
+	for i := range l.Items {
+		cr := &l.Items[i]
+		ref := cr.GetProviderConfigReference()
+		if ref == nil || ref.Name != cp.providerConfig {
+			continue
+		}
+		if _, ok := changed[connectorName(cr)]; !ok {
+			continue
+		}
+		select {
+		case p.events <- event.GenericEvent{Object: cr}:
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+// A clusterPoller caches the connectors of one Kafka Connect cluster.
+type clusterPoller struct {
+	providerConfig string
+	maxAge         time.Duration
+
+	mu          sync.RWMutex
+	svc         Service
+	lastUsed    time.Time
+	connectors  map[string]kafkaconnect.ExpandedConnector
+	fetched     time.Time
+	invalidated map[string]time.Time
+	unsupported bool
+}
+
+func (cp *clusterPoller) use(svc Service) {
+	cp.mu.Lock()
+	defer cp.mu.Unlock()
+	cp.svc = svc
+	cp.lastUsed = time.Now()
+}
+
+func (cp *clusterPoller) service() Service {
+	cp.mu.RLock()
+	defer cp.mu.RUnlock()
+	return cp.svc
+}
+
+func (cp *clusterPoller) idleSince() time.Duration {
+	cp.mu.RLock()
+	defer cp.mu.RUnlock()
+	return time.Since(cp.lastUsed)
+}
+
+// markUnsupported records that the cluster cannot list connectors with their
+// status. It returns true if that was not known before.
+func (cp *clusterPoller) markUnsupported() bool {
+	cp.mu.Lock()
+	defer cp.mu.Unlock()
+	known := cp.unsupported
+	cp.unsupported = true
+	return !known
+}
+
+// update replaces the cached connectors and returns the names of those that
+// were added, removed or changed. Nothing is reported as changed by the first
+// poll, since all Connectors are reconciled on startup anyway.
+func (cp *clusterPoller) update(connectors map[string]kafkaconnect.ExpandedConnector, fetched time.Time) map[string]struct{} {
+	cp.mu.Lock()
+	defer cp.mu.Unlock()
+
+	changed := map[string]struct{}{}
+	if cp.connectors != nil {
+		for name, c := range connectors {
+			if prev, ok := cp.connectors[name]; !ok || !reflect.DeepEqual(prev, c) {
+				changed[name] = struct{}{}
+			}
+		}
+		for name := range cp.connectors {
+			if _, ok := connectors[name]; !ok {
+				changed[name] = struct{}{}
+			}
+		}
+	}
+
+	cp.connectors = connectors
+	cp.fetched = fetched
+	cp.unsupported = false
+	for name, t := range cp.invalidated {
+		if fetched.After(t) {
+			delete(cp.invalidated, name)
+		}
+	}
+	return changed
+}
+
+// Get returns the cached info and status of the named connector. It returns
+// false if the connector is not cached, if it was invalidated after the cache
+// was last refreshed, or if the cache is stale.
+func (cp *clusterPoller) Get(name string) (kafkaconnect.ExpandedConnector, bool) {
+	cp.mu.RLock()
+	defer cp.mu.RUnlock()
+
+	if time.Since(cp.fetched) > cp.maxAge {
+		return kafkaconnect.ExpandedConnector{}, false
+	}
+	if _, ok := cp.invalidated[name]; ok {
+		return kafkaconnect.ExpandedConnector{}, false
+	}
+	c, ok := cp.connectors[name]
+	if !ok || c.Info == nil || c.Status == nil {
+		return kafkaconnect.ExpandedConnector{}, false
+	}
+	return c, true
+}
+
+// Invalidate the cached state of the named connector, e.g. because it was
+// just created or updated. The connector is observed directly until the next
+// poll that started after this call.
+func (cp *clusterPoller) Invalidate(name string) {
+	cp.mu.Lock()
+	defer cp.mu.Unlock()
+	cp.invalidated[name] = time.Now()
+}
@@ -0,0 +1,127 @@
+/*
+Copyright 2025 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package connector
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/crossplane/provider-kafkaconnect/internal/clients/kafkaconnect"
+)
+
+func expanded(state string) kafkaconnect.ExpandedConnector {
+	return kafkaconnect.ExpandedConnector{
+		Info:   &kafkaconnect.ConnectorInfo{Name: "c"},
+		Status: &kafkaconnect.ConnectorStatus{Connector: kafkaconnect.ConnectorState{State: state}},
+	}
+}
+
+func TestClusterPollerUpdate(t *testing.T) {
+	type args struct {
+		previous map[string]kafkaconnect.ExpandedConnector
+		current  map[string]kafkaconnect.ExpandedConnector
+	}
+
+	cases := map[string]struct {
+		reason string
+		args   args
+		want   map[string]struct{}
+	}{
+		"FirstPoll": {
+			reason: "The first poll should not report any connector as changed.",
+			args: args{
+				current: map[string]kafkaconnect.ExpandedConnector{"a": expanded("RUNNING")},
+			},
+			want: map[string]struct{}{},
+		},
+		"Changed": {
+			reason: "Connectors that were added, removed or changed state should be reported.",
+			args: args{
+				previous: map[string]kafkaconnect.ExpandedConnector{
+					"same":    expanded("RUNNING"),
+					"changed": expanded("RUNNING"),
+					"removed": expanded("RUNNING"),
+				},
+				current: map[string]kafkaconnect.ExpandedConnector{
+					"same":    expanded("RUNNING"),
+					"changed": expanded("FAILED"),
+					"added":   expanded("RUNNING"),
+				},
+			},
+			want: map[string]struct{}{"changed": {}, "removed": {}, "added": {}},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			cp := &clusterPoller{maxAge: time.Minute, invalidated: map[string]time.Time{}}
+			if tc.args.previous != nil {
+				cp.update(tc.args.previous, time.Now())
+			}
+			got := cp.update(tc.args.current, time.Now())
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("\n%s\ncp.update(...): -want, +got:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestClusterPollerGet(t *testing.T) {
+	cp := &clusterPoller{maxAge: time.Minute, invalidated: map[string]time.Time{}}
+	before := time.Now()
+	cp.update(map[string]kafkaconnect.ExpandedConnector{"a": expanded("RUNNING")}, before)
+
+	if _, ok := cp.Get("a"); !ok {
+		t.Errorf("cp.Get(...): want cached connector")
+	}
+
+	cp.Invalidate("a")
+	if _, ok := cp.Get("a"); ok {
+		t.Errorf("cp.Get(...): want invalidated connector to miss")
+	}
+
+	// A poll that started before the connector was invalidated must not
+	// revalidate it.
+	cp.update(map[string]kafkaconnect.ExpandedConnector{"a": expanded("RUNNING")}, before)
+	if _, ok := cp.Get("a"); ok {
+		t.Errorf("cp.Get(...): want connector to miss until a newer poll")
+	}
+
+	cp.update(map[string]kafkaconnect.ExpandedConnector{"a": expanded("RUNNING")}, time.Now().Add(time.Second))
+	if _, ok := cp.Get("a"); !ok {
+		t.Errorf("cp.Get(...): want connector refreshed by a newer poll")
+	}
+}
+
+func TestClusterPollerMarkUnsupported(t *testing.T) {
+	cp := &clusterPoller{maxAge: time.Minute, invalidated: map[string]time.Time{}}
+
+	if !cp.markUnsupported() {
+		t.Errorf("cp.markUnsupported(): want true the first time")
+	}
+	if cp.markUnsupported() {
+		t.Errorf("cp.markUnsupported(): want false once known")
+	}
+
+	// A successful poll, e.g. after the cluster was upgraded, resets it.
+	cp.update(map[string]kafkaconnect.ExpandedConnector{}, time.Now())
+	if !cp.markUnsupported() {
+		t.Errorf("cp.markUnsupported(): want true after a successful poll")
+	}
+}
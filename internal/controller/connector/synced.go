@@ -0,0 +1,75 @@
+/*
+Copyright 2025 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package connector
+
+import (
+	"context"
+	"strings"
+
+	"github.com/pkg/errors"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
+
+	"github.com/crossplane/provider-kafkaconnect/apis/kafkaconnect/v1alpha1"
+)
+
+const errUpdateSynced = "cannot update Synced condition of Connector"
+
+// A syncedReconciler gives the Synced condition of Connectors that could not
+// be reconciled because their Kafka Connect cluster is unavailable its own
+// reason. The managed reconciler reports every failure as a ReconcileError.
+type syncedReconciler struct {
+	reconcile.Reconciler
+	kube      client.Client
+	newObject func() resource.Managed
+}
+
+// Reconcile the supplied Connector, then replace its Synced condition if the
+// reconcile failed because its Kafka Connect cluster is unavailable.
+func (r *syncedReconciler) Reconcile(ctx context.Context, req reconcile.Request) (reconcile.Result, error) {
+	result, err := r.Reconciler.Reconcile(ctx, req)
+
+	mg := r.newObject()
+	if gerr := r.kube.Get(ctx, req.NamespacedName, mg); gerr != nil || !clusterUnavailable(mg) {
+		return result, err
+	}
+
+	synced := mg.GetCondition(xpv1.TypeSynced)
+	mg.SetConditions(v1alpha1.ReconcileClusterUnavailable(errors.New(synced.Message)))
+
+	// A conflict means the Connector changed since it was reconciled, so it
+	// will be reconciled again.
+	if uerr := r.kube.Status().Update(ctx, mg); uerr != nil && !kerrors.IsConflict(uerr) {
+		return result, errors.Wrap(uerr, errUpdateSynced)
+	}
+	return result, err
+}
+
+// clusterUnavailable returns true if the last reconcile of the supplied
+// Connector failed because its Kafka Connect cluster is unavailable. The
+// Ready condition may be left over from an earlier reconcile, but then its
+// message would differ, since it includes when the breaker probes again.
+func clusterUnavailable(mg resource.Managed) bool {
+	ready, synced := mg.GetCondition(xpv1.TypeReady), mg.GetCondition(xpv1.TypeSynced)
+	return ready.Reason == v1alpha1.ReasonClusterUnavailable &&
+		synced.Reason == xpv1.ReasonReconcileError &&
+		strings.HasSuffix(synced.Message, ready.Message)
+}
@@ -0,0 +1,97 @@
+/*
+Copyright 2025 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package connector
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	"github.com/pkg/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+
+	"github.com/crossplane/provider-kafkaconnect/apis/kafkaconnect/v1alpha1"
+	"github.com/crossplane/provider-kafkaconnect/internal/clients/kafkaconnect"
+)
+
+func TestSyncedReconcile(t *testing.T) {
+	unavailable := &kafkaconnect.UnavailableError{RetryAfter: 30 * time.Second}
+	earlier := &kafkaconnect.UnavailableError{RetryAfter: 10 * time.Second}
+	errBoom := errors.New("boom")
+
+	cases := map[string]struct {
+		reason     string
+		conditions []xpv1.Condition
+		want       []xpv1.Condition
+	}{
+		"ClusterUnavailable": {
+			reason:     "A Connector that was not reconciled because its cluster is unavailable should say so on its Synced condition.",
+			conditions: []xpv1.Condition{v1alpha1.ClusterUnavailable(unavailable), xpv1.ReconcileError(errors.Wrap(unavailable, "connect failed"))},
+			want:       []xpv1.Condition{v1alpha1.ClusterUnavailable(unavailable), v1alpha1.ReconcileClusterUnavailable(errors.Wrap(unavailable, "connect failed"))},
+		},
+		"OtherError": {
+			reason:     "A Connector that was not reconciled for another reason should keep its ReconcileError, even if its cluster was unavailable before.",
+			conditions: []xpv1.Condition{v1alpha1.ClusterUnavailable(earlier), xpv1.ReconcileError(errBoom)},
+		},
+		"Synced": {
+			reason:     "A Connector that was reconciled should keep its Synced condition.",
+			conditions: []xpv1.Condition{xpv1.Available(), xpv1.ReconcileSuccess()},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			var got []xpv1.Condition
+			kube := &test.MockClient{
+				MockGet: test.NewMockGetFn(nil, func(obj client.Object) error {
+					obj.(*v1alpha1.Connector).SetConditions(tc.conditions...)
+					return nil
+				}),
+				MockStatusUpdate: func(_ context.Context, obj client.Object, _ ...client.SubResourceUpdateOption) error {
+					got = obj.(*v1alpha1.Connector).Status.Conditions
+					return nil
+				},
+			}
+			r := &syncedReconciler{
+				Reconciler: reconcile.Func(func(_ context.Context, _ reconcile.Request) (reconcile.Result, error) {
+					return reconcile.Result{Requeue: true}, nil
+				}),
+				kube:      kube,
+				newObject: func() resource.Managed { return &v1alpha1.Connector{} },
+			}
+
+			result, err := r.Reconcile(context.Background(), reconcile.Request{})
+			if err != nil {
+				t.Fatalf("\n%s\nr.Reconcile(...): %v", tc.reason, err)
+			}
+			if !result.Requeue {
+				t.Errorf("\n%s\nr.Reconcile(...): want the result of the managed reconciler", tc.reason)
+			}
+			if diff := cmp.Diff(tc.want, got, cmpopts.IgnoreTypes(metav1.Time{})); diff != "" {
+				t.Errorf("\n%s\nr.Reconcile(...): -want updated conditions, +got:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
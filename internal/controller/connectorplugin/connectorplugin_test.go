@@ -21,10 +21,13 @@ import (
 	"testing"
 
 	"github.com/google/go-cmp/cmp"
+	"github.com/pkg/errors"
 
 	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
 	"github.com/crossplane/crossplane-runtime/pkg/resource"
 	"github.com/crossplane/crossplane-runtime/pkg/test"
+
+	"github.com/crossplane/provider-kafkaconnect/apis/kafkaconnect/v1alpha1"
 )
 
 // Unlike many Kubernetes projects Crossplane does not use third party testing
@@ -56,7 +59,20 @@ func TestObserve(t *testing.T) {
 		args   args
 		want   want
 	}{
-		// TODO: Add test cases.
+		"NotConnectorPlugin": {
+			reason: "An error should be returned if the managed resource is not a ConnectorPlugin.",
+			args:   args{ctx: context.Background(), mg: &v1alpha1.Connector{}},
+			want:   want{err: errors.New(errNotConnectorPlugin)},
+		},
+		"Observed": {
+			reason: "ConnectorPlugins are not managed yet, so they should always be reported as existing and up to date.",
+			args:   args{ctx: context.Background(), mg: &v1alpha1.ConnectorPlugin{}},
+			want: want{o: managed.ExternalObservation{
+				ResourceExists:    true,
+				ResourceUpToDate:  true,
+				ConnectionDetails: managed.ConnectionDetails{},
+			}},
+		},
 	}
 
 	for name, tc := range cases {
@@ -0,0 +1,659 @@
+//go:build integration
+
+/*
+Copyright 2025 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// The integration suite runs the controllers against a real API server
+// started by envtest, and an in-memory Kafka Connect server. It needs the
+// envtest binaries, e.g. installed by setup-envtest:
+//
+//	KUBEBUILDER_ASSETS=$(setup-envtest use -p path) go test -tags integration ./internal/controller/...
+package controller_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	extv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/util/retry"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/envtest"
+	metricsserver "sigs.k8s.io/controller-runtime/pkg/metrics/server"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	xpcontroller "github.com/crossplane/crossplane-runtime/pkg/controller"
+	"github.com/crossplane/crossplane-runtime/pkg/feature"
+	"github.com/crossplane/crossplane-runtime/pkg/logging"
+	"github.com/crossplane/crossplane-runtime/pkg/meta"
+	"github.com/crossplane/crossplane-runtime/pkg/ratelimiter"
+
+	"github.com/crossplane/provider-kafkaconnect/apis"
+	"github.com/crossplane/provider-kafkaconnect/apis/kafkaconnect/v1alpha1"
+	nskcv1alpha1 "github.com/crossplane/provider-kafkaconnect/apis/namespaced/kafkaconnect/v1alpha1"
+	nsv1alpha1 "github.com/crossplane/provider-kafkaconnect/apis/namespaced/v1alpha1"
+	pcv1alpha1 "github.com/crossplane/provider-kafkaconnect/apis/v1alpha1"
+	"github.com/crossplane/provider-kafkaconnect/internal/clients/kafkaconnect/fake"
+	"github.com/crossplane/provider-kafkaconnect/internal/controller"
+	"github.com/crossplane/provider-kafkaconnect/internal/options"
+)
+
+const (
+	namespace = "default"
+	username  = "admin"
+	password  = "secret"
+
+	pollInterval = time.Second
+	timeout      = time.Minute
+	interval     = 250 * time.Millisecond
+
+	finalizerInUse = "in-use.crossplane.io"
+)
+
+// kube talks to the API server directly, bypassing the manager's cache.
+var kube client.Client
+
+func TestMain(m *testing.M) {
+	if os.Getenv("KUBEBUILDER_ASSETS") == "" {
+		// Each test is skipped by setup.
+		os.Exit(m.Run())
+	}
+	os.Exit(run(m))
+}
+
+func run(m *testing.M) int {
+	env := &envtest.Environment{
+		CRDDirectoryPaths:     []string{filepath.Join("..", "..", "package", "crds")},
+		ErrorIfCRDPathMissing: true,
+	}
+	cfg, err := env.Start()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "cannot start envtest: %v\n", err)
+		return 1
+	}
+	defer env.Stop() //nolint:errcheck // Nothing to do if stopping fails.
+
+	s := runtime.NewScheme()
+	if err := clientgoscheme.AddToScheme(s); err != nil {
+		fmt.Fprintf(os.Stderr, "cannot add client-go APIs to scheme: %v\n", err)
+		return 1
+	}
+	if err := apis.AddToScheme(s); err != nil {
+		fmt.Fprintf(os.Stderr, "cannot add KafkaConnect APIs to scheme: %v\n", err)
+		return 1
+	}
+
+	mgr, err := ctrl.NewManager(cfg, ctrl.Options{
+		Scheme:  s,
+		Metrics: metricsserver.Options{BindAddress: "0"},
+	})
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "cannot create controller manager: %v\n", err)
+		return 1
+	}
+
+	o := options.Options{
+		Options: xpcontroller.Options{
+			Logger:                  logging.NewNopLogger(),
+			MaxConcurrentReconciles: 5,
+			PollInterval:            pollInterval,
+			GlobalRateLimiter:       ratelimiter.NewGlobal(100),
+			Features:                &feature.Flags{},
+		},
+		HealthCheckInterval: time.Minute,
+	}
+	if err := controller.Setup(mgr, o); err != nil {
+		fmt.Fprintf(os.Stderr, "cannot setup controllers: %v\n", err)
+		return 1
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go func() {
+		if err := mgr.Start(ctx); err != nil {
+			fmt.Fprintf(os.Stderr, "cannot start controller manager: %v\n", err)
+			os.Exit(1)
+		}
+	}()
+
+	kube, err = client.New(cfg, client.Options{Scheme: s})
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "cannot create client: %v\n", err)
+		return 1
+	}
+
+	return m.Run()
+}
+
+func TestConnectorLifecycle(t *testing.T) {
+	srv, pc := setup(t, "lifecycle")
+	cr := connector("lifecycle", pc, map[string]string{"file": "/tmp/create"})
+	create(t, cr)
+
+	eventually(t, "the connector should be created", func() error {
+		if err := wantFile(srv, cr, "/tmp/create"); err != nil {
+			return err
+		}
+		return wantCondition(cr, xpv1.TypeReady, corev1.ConditionTrue)
+	})
+
+	// Change the connector behind the provider's back.
+	drift := map[string]string{"connector.class": cr.Spec.ForProvider.ConnectorClass, "file": "/tmp/drift"}
+	if err := putConfig(srv, cr.Spec.ForProvider.Name, drift); err != nil {
+		t.Fatalf("cannot change connector config: %v", err)
+	}
+	eventually(t, "drift should be corrected", func() error {
+		return wantFile(srv, cr, "/tmp/create")
+	})
+
+	update(t, cr, func() {
+		cr.Spec.ForProvider.Config["file"] = stringValue("/tmp/update")
+	})
+	eventually(t, "the connector should be updated", func() error {
+		return wantFile(srv, cr, "/tmp/update")
+	})
+
+	update(t, cr, func() {
+		meta.AddAnnotations(cr, map[string]string{meta.AnnotationKeyReconciliationPaused: "true"})
+		cr.Spec.ForProvider.Config["file"] = stringValue("/tmp/paused")
+	})
+	eventually(t, "reconciliation should be paused", func() error {
+		return wantReason(cr, xpv1.TypeSynced, xpv1.ReasonReconcilePaused)
+	})
+	time.Sleep(3 * pollInterval)
+	if err := wantFile(srv, cr, "/tmp/update"); err != nil {
+		t.Fatalf("a paused connector should not be updated: %v", err)
+	}
+
+	update(t, cr, func() {
+		meta.RemoveAnnotations(cr, meta.AnnotationKeyReconciliationPaused)
+	})
+	eventually(t, "the connector should be updated once resumed", func() error {
+		return wantFile(srv, cr, "/tmp/paused")
+	})
+
+	if err := kube.Delete(context.Background(), cr); err != nil {
+		t.Fatalf("cannot delete connector: %v", err)
+	}
+	eventually(t, "the connector should be deleted", func() error {
+		if _, ok := srv.Connector(cr.Spec.ForProvider.Name); ok {
+			return fmt.Errorf("connector %q still exists in Kafka Connect", cr.Spec.ForProvider.Name)
+		}
+		return wantGone(cr)
+	})
+}
+
+func TestProviderConfigUsage(t *testing.T) {
+	_, pc := setup(t, "usage")
+	cr := connector("usage", pc, map[string]string{"file": "/tmp/usage"})
+	create(t, cr)
+
+	eventually(t, "the ProviderConfig usage should be tracked", func() error {
+		l := &pcv1alpha1.ProviderConfigUsageList{}
+		if err := kube.List(context.Background(), l); err != nil {
+			return err
+		}
+		for _, u := range l.Items {
+			if u.ProviderConfigReference.Name == pc.GetName() && u.ResourceReference.Name == cr.GetName() {
+				return nil
+			}
+		}
+		return fmt.Errorf("no ProviderConfigUsage of %q by %q", pc.GetName(), cr.GetName())
+	})
+
+	eventually(t, "the ProviderConfig should be finalized", func() error {
+		if err := kube.Get(context.Background(), client.ObjectKeyFromObject(pc), pc); err != nil {
+			return err
+		}
+		if !meta.FinalizerExists(pc, finalizerInUse) {
+			return fmt.Errorf("ProviderConfig %q has no %s finalizer", pc.GetName(), finalizerInUse)
+		}
+		return nil
+	})
+
+	if err := kube.Delete(context.Background(), pc); err != nil {
+		t.Fatalf("cannot delete ProviderConfig: %v", err)
+	}
+	time.Sleep(3 * pollInterval)
+	if err := kube.Get(context.Background(), client.ObjectKeyFromObject(pc), pc); err != nil {
+		t.Fatalf("a ProviderConfig in use should not be deleted: %v", err)
+	}
+	if pc.GetDeletionTimestamp() == nil {
+		t.Fatalf("ProviderConfig %q should be deleting", pc.GetName())
+	}
+
+	if err := kube.Delete(context.Background(), cr); err != nil {
+		t.Fatalf("cannot delete connector: %v", err)
+	}
+	eventually(t, "the ProviderConfig should be deleted once unused", func() error {
+		return wantGone(pc)
+	})
+}
+
+func TestSecretRotation(t *testing.T) {
+	srv, pc := setup(t, "rotation")
+	cr := connector("rotation", pc, map[string]string{"file": "/tmp/rotation"})
+	create(t, cr)
+
+	eventually(t, "the connector should be synced", func() error {
+		return wantCondition(cr, xpv1.TypeSynced, corev1.ConditionTrue)
+	})
+
+	srv.SetBasicAuth(username, "rotated")
+	eventually(t, "the connector should not sync with stale credentials", func() error {
+		return wantCondition(cr, xpv1.TypeSynced, corev1.ConditionFalse)
+	})
+
+	s := &corev1.Secret{ObjectMeta: metav1.ObjectMeta{Namespace: namespace, Name: pc.GetName()}}
+	update(t, s, func() {
+		s.Data = map[string][]byte{"credentials": credentials(username, "rotated")}
+	})
+	eventually(t, "the connector should sync with the rotated credentials", func() error {
+		return wantCondition(cr, xpv1.TypeSynced, corev1.ConditionTrue)
+	})
+
+	update(t, cr, func() {
+		cr.Spec.ForProvider.Config["file"] = stringValue("/tmp/rotated")
+	})
+	eventually(t, "the connector should be updated with the rotated credentials", func() error {
+		return wantFile(srv, cr, "/tmp/rotated")
+	})
+}
+
+func TestConnectorValidation(t *testing.T) {
+	_, pc := setup(t, "validation")
+
+	cases := map[string]struct {
+		reason string
+		modify func(cr *v1alpha1.Connector)
+	}{
+		"TasksMax": {
+			reason: "The maximum number of tasks must be positive.",
+			modify: func(cr *v1alpha1.Connector) { cr.Spec.ForProvider.TasksMax = -1 },
+		},
+		"URLScheme": {
+			reason: "The Kafka Connect URL must be an http or https URL.",
+			modify: func(cr *v1alpha1.Connector) { cr.Spec.ForProvider.KafkaConnectURL = "ftp://connect:8083" },
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			cr := connector("invalid", pc, map[string]string{"file": "/tmp/invalid"})
+			tc.modify(cr)
+			if err := kube.Create(context.Background(), cr); !kerrors.IsInvalid(err) {
+				_ = kube.Delete(context.Background(), cr)
+				t.Errorf("%s: want invalid error, got %v", tc.reason, err)
+			}
+		})
+	}
+
+	t.Run("ImmutableName", func(t *testing.T) {
+		cr := connector("immutable", pc, map[string]string{"file": "/tmp/immutable"})
+		create(t, cr)
+		err := retry.RetryOnConflict(retry.DefaultRetry, func() error {
+			if err := kube.Get(context.Background(), client.ObjectKeyFromObject(cr), cr); err != nil {
+				return err
+			}
+			cr.Spec.ForProvider.Name = "renamed"
+			return kube.Update(context.Background(), cr)
+		})
+		if !kerrors.IsInvalid(err) {
+			t.Errorf("The name of a connector should be immutable: want invalid error, got %v", err)
+		}
+	})
+}
+
+func TestNamespacedConnector(t *testing.T) {
+	srv, cpc := setup(t, "namespaced")
+	pc := &nsv1alpha1.ProviderConfig{
+		ObjectMeta: metav1.ObjectMeta{Namespace: namespace, Name: "namespaced"},
+		Spec:       cpc.Spec,
+	}
+	create(t, pc)
+
+	cr := &nskcv1alpha1.Connector{
+		ObjectMeta: metav1.ObjectMeta{Namespace: namespace, Name: "namespaced"},
+		Spec: nskcv1alpha1.ConnectorSpec{
+			ProviderConfigReference: &nsv1alpha1.ProviderConfigReference{Kind: nsv1alpha1.ProviderConfigKind, Name: pc.GetName()},
+			ForProvider:             connector("namespaced", cpc, map[string]string{"file": "/tmp/namespaced"}).Spec.ForProvider,
+		},
+	}
+	create(t, cr)
+
+	eventually(t, "the connector should be created and ready", func() error {
+		if _, ok := srv.Connector(cr.Spec.ForProvider.Name); !ok {
+			return fmt.Errorf("connector %q does not exist in Kafka Connect", cr.Spec.ForProvider.Name)
+		}
+		if err := kube.Get(context.Background(), client.ObjectKeyFromObject(cr), cr); err != nil {
+			return err
+		}
+		if c := cr.GetCondition(xpv1.TypeReady); c.Status != corev1.ConditionTrue {
+			return fmt.Errorf("connector %q is Ready=%s (%s: %s)", cr.GetName(), c.Status, c.Reason, c.Message)
+		}
+		return nil
+	})
+
+	eventually(t, "the ProviderConfig should be finalized", func() error {
+		if err := kube.Get(context.Background(), client.ObjectKeyFromObject(pc), pc); err != nil {
+			return err
+		}
+		if !meta.FinalizerExists(pc, finalizerInUse) {
+			return fmt.Errorf("ProviderConfig %q has no %s finalizer", pc.GetName(), finalizerInUse)
+		}
+		return nil
+	})
+
+	// The credentials Secret of the spec copied from the cluster scoped
+	// ProviderConfig is in the namespace; point at one outside of it.
+	update(t, pc, func() { pc.Spec.Credentials.SecretRef.Namespace = "kube-system" })
+	eventually(t, "credentials outside of the namespace should not be used", func() error {
+		if err := kube.Get(context.Background(), client.ObjectKeyFromObject(cr), cr); err != nil {
+			return err
+		}
+		if c := cr.GetCondition(xpv1.TypeSynced); c.Status != corev1.ConditionFalse {
+			return fmt.Errorf("connector %q is Synced=%s, want False", cr.GetName(), c.Status)
+		}
+		return nil
+	})
+	update(t, pc, func() { pc.Spec.Credentials.SecretRef.Namespace = namespace })
+
+	if err := kube.Delete(context.Background(), cr); err != nil {
+		t.Fatalf("cannot delete connector: %v", err)
+	}
+	eventually(t, "the connector should be deleted", func() error {
+		if _, ok := srv.Connector(cr.Spec.ForProvider.Name); ok {
+			return fmt.Errorf("connector %q still exists in Kafka Connect", cr.Spec.ForProvider.Name)
+		}
+		return wantGone(cr)
+	})
+
+	if err := kube.Delete(context.Background(), pc); err != nil {
+		t.Fatalf("cannot delete ProviderConfig: %v", err)
+	}
+	eventually(t, "the ProviderConfig should be deleted once unused", func() error {
+		return wantGone(pc)
+	})
+}
+
+func TestClusterProviderConfigTenants(t *testing.T) {
+	srv, pc := setup(t, "tenants")
+	cpc := &nsv1alpha1.ClusterProviderConfig{
+		ObjectMeta: metav1.ObjectMeta{Name: "tenants"},
+		Spec:       pc.Spec,
+	}
+	create(t, cpc)
+	for _, ns := range []string{"team-a", "team-b"} {
+		create(t, &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: ns}})
+	}
+
+	tenant := func(namespace, name, connectName, file string) *nskcv1alpha1.Connector {
+		cr := &nskcv1alpha1.Connector{
+			ObjectMeta: metav1.ObjectMeta{Namespace: namespace, Name: name},
+			Spec: nskcv1alpha1.ConnectorSpec{
+				ProviderConfigReference: &nsv1alpha1.ProviderConfigReference{Kind: nsv1alpha1.ClusterProviderConfigKind, Name: cpc.GetName()},
+				ForProvider:             connector(connectName, pc, map[string]string{"file": file}).Spec.ForProvider,
+			},
+		}
+		create(t, cr)
+		return cr
+	}
+	file := func(name string) string {
+		c, ok := srv.Connector(name)
+		if !ok {
+			return ""
+		}
+		return c.Config["file"]
+	}
+
+	// Both namespaces use the same name, and team-b tries to use the name
+	// of the connector of team-a.
+	tenant("team-a", "shared", "shared", "/tmp/team-a")
+	tenant("team-b", "shared", "shared", "/tmp/team-b")
+	tenant("team-b", "takeover", "team-a.shared", "/tmp/takeover")
+
+	eventually(t, "each namespace should get its own connectors", func() error {
+		want := map[string]string{
+			"team-a.shared":        "/tmp/team-a",
+			"team-b.shared":        "/tmp/team-b",
+			"team-b.team-a.shared": "/tmp/takeover",
+		}
+		for name, f := range want {
+			if got := file(name); got != f {
+				return fmt.Errorf("connector %q has file %q, want %q", name, got, f)
+			}
+		}
+		return nil
+	})
+
+	// A connector of the namespace that was not created by a Connector must
+	// not be adopted.
+	if err := putConfig(srv, "team-b.existing", map[string]string{
+		"connector.class": "org.apache.kafka.connect.file.FileStreamSourceConnector",
+		"tasks.max":       "1",
+		"file":            "/tmp/existing",
+	}); err != nil {
+		t.Fatalf("cannot create connector: %v", err)
+	}
+	cr := tenant("team-b", "existing", "existing", "/tmp/adopted")
+	eventually(t, "an existing connector should not be adopted", func() error {
+		if err := kube.Get(context.Background(), client.ObjectKeyFromObject(cr), cr); err != nil {
+			return err
+		}
+		if c := cr.GetCondition(xpv1.TypeSynced); c.Status != corev1.ConditionFalse {
+			return fmt.Errorf("connector %q is Synced=%s, want False", cr.GetName(), c.Status)
+		}
+		return nil
+	})
+	if got := file("team-b.existing"); got != "/tmp/existing" {
+		t.Errorf("connector %q has file %q, want it untouched", "team-b.existing", got)
+	}
+
+	if err := kube.Delete(context.Background(), cr); err != nil {
+		t.Fatalf("cannot delete connector: %v", err)
+	}
+	eventually(t, "deleting the Connector should leave the existing connector", func() error {
+		return wantGone(cr)
+	})
+	if _, ok := srv.Connector("team-b.existing"); !ok {
+		t.Errorf("connector %q was deleted by a Connector that did not create it", "team-b.existing")
+	}
+}
+
+// setup starts a Kafka Connect server requiring basic auth, and creates a
+// ProviderConfig with a credentials Secret for it. Both are named after the
+// test.
+func setup(t *testing.T, name string) (*fake.Server, *pcv1alpha1.ProviderConfig) {
+	t.Helper()
+	if kube == nil {
+		t.Skip("KUBEBUILDER_ASSETS is not set")
+	}
+
+	srv := fake.NewServer(fake.WithBasicAuth(username, password))
+	t.Cleanup(srv.Close)
+
+	s := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Namespace: namespace, Name: name},
+		Data:       map[string][]byte{"credentials": credentials(username, password)},
+	}
+	create(t, s)
+
+	pc := &pcv1alpha1.ProviderConfig{
+		ObjectMeta: metav1.ObjectMeta{Name: name},
+		Spec: pcv1alpha1.ProviderConfigSpec{
+			KafkaConnectURL: srv.URL,
+			Credentials: pcv1alpha1.ProviderCredentials{
+				Source: xpv1.CredentialsSourceSecret,
+				CommonCredentialSelectors: xpv1.CommonCredentialSelectors{
+					SecretRef: &xpv1.SecretKeySelector{
+						SecretReference: xpv1.SecretReference{Namespace: namespace, Name: name},
+						Key:             "credentials",
+					},
+				},
+			},
+		},
+	}
+	create(t, pc)
+
+	return srv, pc
+}
+
+func connector(name string, pc *pcv1alpha1.ProviderConfig, config map[string]string) *v1alpha1.Connector {
+	cr := &v1alpha1.Connector{
+		ObjectMeta: metav1.ObjectMeta{Name: name},
+		Spec: v1alpha1.ConnectorSpec{
+			ResourceSpec: xpv1.ResourceSpec{
+				ProviderConfigReference: &xpv1.Reference{Name: pc.GetName()},
+			},
+			ForProvider: v1alpha1.ConnectorParameters{
+				Name:           name,
+				ConnectorClass: "org.apache.kafka.connect.file.FileStreamSourceConnector",
+				TasksMax:       1,
+				Config:         make(map[string]extv1.JSON, len(config)),
+			},
+		},
+	}
+	for k, v := range config {
+		cr.Spec.ForProvider.Config[k] = stringValue(v)
+	}
+	return cr
+}
+
+func stringValue(s string) extv1.JSON {
+	b, _ := json.Marshal(s)
+	return extv1.JSON{Raw: b}
+}
+
+func credentials(username, password string) []byte {
+	b, _ := json.Marshal(map[string]string{"username": username, "password": password})
+	return b
+}
+
+// create the supplied object, and delete it once the test is done.
+func create(t *testing.T, o client.Object) {
+	t.Helper()
+	if err := kube.Create(context.Background(), o); err != nil {
+		t.Fatalf("cannot create %T %q: %v", o, o.GetName(), err)
+	}
+	t.Cleanup(func() {
+		_ = client.IgnoreNotFound(kube.Delete(context.Background(), o))
+	})
+}
+
+// update the supplied object with the supplied mutation, retrying on
+// conflicts.
+func update(t *testing.T, o client.Object, mutate func()) {
+	t.Helper()
+	err := retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		if err := kube.Get(context.Background(), client.ObjectKeyFromObject(o), o); err != nil {
+			return err
+		}
+		mutate()
+		return kube.Update(context.Background(), o)
+	})
+	if err != nil {
+		t.Fatalf("cannot update %T %q: %v", o, o.GetName(), err)
+	}
+}
+
+// eventually calls fn until it succeeds, failing the test on timeout.
+func eventually(t *testing.T, reason string, fn func() error) {
+	t.Helper()
+	var err error
+	for deadline := time.Now().Add(timeout); time.Now().Before(deadline); time.Sleep(interval) {
+		if err = fn(); err == nil {
+			return
+		}
+	}
+	t.Fatalf("%s: %v", reason, err)
+}
+
+func wantFile(srv *fake.Server, cr *v1alpha1.Connector, file string) error {
+	c, ok := srv.Connector(cr.Spec.ForProvider.Name)
+	if !ok {
+		return fmt.Errorf("connector %q does not exist in Kafka Connect", cr.Spec.ForProvider.Name)
+	}
+	if c.Config["file"] != file {
+		return fmt.Errorf("connector %q has file %q, want %q", c.Name, c.Config["file"], file)
+	}
+	return nil
+}
+
+func wantCondition(cr *v1alpha1.Connector, ct xpv1.ConditionType, status corev1.ConditionStatus) error {
+	if err := kube.Get(context.Background(), client.ObjectKeyFromObject(cr), cr); err != nil {
+		return err
+	}
+	if c := cr.GetCondition(ct); c.Status != status {
+		return fmt.Errorf("connector %q is %s=%s (%s: %s), want %s", cr.GetName(), ct, c.Status, c.Reason, c.Message, status)
+	}
+	return nil
+}
+
+func wantReason(cr *v1alpha1.Connector, ct xpv1.ConditionType, reason xpv1.ConditionReason) error {
+	if err := kube.Get(context.Background(), client.ObjectKeyFromObject(cr), cr); err != nil {
+		return err
+	}
+	if c := cr.GetCondition(ct); c.Reason != reason {
+		return fmt.Errorf("connector %q has %s reason %q, want %q", cr.GetName(), ct, c.Reason, reason)
+	}
+	return nil
+}
+
+func wantGone(o client.Object) error {
+	err := kube.Get(context.Background(), client.ObjectKeyFromObject(o), o)
+	if kerrors.IsNotFound(err) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	return fmt.Errorf("%T %q still exists", o, o.GetName())
+}
+
+// putConfig sets the config of the named connector directly in Kafka Connect.
+func putConfig(srv *fake.Server, name string, config map[string]string) error {
+	body, err := json.Marshal(config)
+	if err != nil {
+		return err
+	}
+	req, err := http.NewRequest(http.MethodPut, srv.URL+"/connectors/"+name+"/config", bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.SetBasicAuth(username, password)
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close() //nolint:errcheck // Nothing to do if closing fails.
+	if resp.StatusCode >= http.StatusBadRequest {
+		return fmt.Errorf("PUT config of %q answered %s", name, resp.Status)
+	}
+	return nil
+}
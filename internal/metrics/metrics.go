@@ -0,0 +1,172 @@
+/*
+Copyright 2025 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package metrics contains the Kafka Connect specific Prometheus metrics
+// exported by the provider.
+package metrics
+
+import (
+	"strconv"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+const namespace = "provider_kafkaconnect"
+
+// Label names.
+const (
+	LabelConnector      = "connector"
+	LabelProviderConfig = "providerconfig"
+	LabelWorker         = "worker"
+	LabelState          = "state"
+	LabelTask           = "task"
+	LabelOperation      = "operation"
+	LabelMethod         = "method"
+	LabelEndpoint       = "endpoint"
+	LabelCode           = "code"
+)
+
+// CodeTransportError is the code label value of Kafka Connect requests that
+// failed without an HTTP response, e.g. because the connection was refused.
+const CodeTransportError = "transport_error"
+
+// Operations recorded by the ConnectorFailures counter.
+const (
+	OperationCreate = "create"
+	OperationUpdate = "update"
+)
+
+var (
+	// ConnectorState is set to 1 for the state a connector was last observed
+	// in. Label sets of previously observed states are removed.
+	ConnectorState = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: namespace,
+		Name:      "connector_state",
+		Help:      "State of a Kafka Connect connector as last observed by the provider. The value is always 1.",
+	}, []string{LabelConnector, LabelProviderConfig, LabelWorker, LabelState})
+
+	// TaskState is set to 1 for the state each task of a connector was last
+	// observed in.
+	TaskState = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: namespace,
+		Name:      "connector_task_state",
+		Help:      "State of a Kafka Connect connector task as last observed by the provider. The value is always 1.",
+	}, []string{LabelConnector, LabelTask, LabelProviderConfig, LabelWorker, LabelState})
+
+	// ConnectorRestarts counts the connector and task restarts issued by the
+	// provider.
+	ConnectorRestarts = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "connector_restarts_total",
+		Help:      "Number of automated connector and task restarts issued by the provider.",
+	}, []string{LabelConnector, LabelProviderConfig})
+
+	// ConnectorFailures counts failed create and update calls.
+	ConnectorFailures = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "connector_operation_failures_total",
+		Help:      "Number of failed Kafka Connect create and update calls.",
+	}, []string{LabelConnector, LabelProviderConfig, LabelOperation})
+
+	// ClientRequests counts requests sent to the Kafka Connect REST API.
+	ClientRequests = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "client_requests_total",
+		Help:      "Number of requests sent to the Kafka Connect REST API.",
+	}, []string{LabelProviderConfig, LabelMethod, LabelEndpoint})
+
+	// ClientRequestErrors counts failed requests to the Kafka Connect REST
+	// API by HTTP status code.
+	ClientRequestErrors = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "client_request_errors_total",
+		Help:      "Number of failed requests to the Kafka Connect REST API by HTTP status code.",
+	}, []string{LabelProviderConfig, LabelMethod, LabelEndpoint, LabelCode})
+
+	// ClientRequestDuration observes the latency of requests to the Kafka
+	// Connect REST API.
+	ClientRequestDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Name:      "client_request_duration_seconds",
+		Help:      "Latency of requests to the Kafka Connect REST API.",
+		Buckets:   []float64{0.005, 0.01, 0.025, 0.05, 0.1, 0.25, 0.5, 1, 2.5, 5, 10, 30},
+	}, []string{LabelProviderConfig, LabelMethod, LabelEndpoint})
+
+	// ClientRequestQueueDuration observes how long requests to the Kafka
+	// Connect REST API wait for the concurrency and rate limits of their
+	// ProviderConfig.
+	ClientRequestQueueDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Name:      "client_request_queue_duration_seconds",
+		Help:      "Time requests to the Kafka Connect REST API wait for the limits of their ProviderConfig.",
+		Buckets:   []float64{0.001, 0.005, 0.01, 0.025, 0.05, 0.1, 0.25, 0.5, 1, 2.5, 5, 10, 30},
+	}, []string{LabelProviderConfig})
+)
+
+// Collectors returns all Kafka Connect specific collectors. They must be
+// registered with a Prometheus registry to be exported.
+func Collectors() []prometheus.Collector {
+	return []prometheus.Collector{
+		ConnectorState,
+		TaskState,
+		ConnectorRestarts,
+		ConnectorFailures,
+		ClientRequests,
+		ClientRequestErrors,
+		ClientRequestDuration,
+		ClientRequestQueueDuration,
+	}
+}
+
+// A Task is the observed state of a single connector task.
+type Task struct {
+	ID     int
+	Worker string
+	State  string
+}
+
+// SetConnectorState records the observed state of a connector and its tasks,
+// replacing any previously recorded state.
+func SetConnectorState(connector, pc, worker, state string, tasks []Task) {
+	l := prometheus.Labels{LabelConnector: connector, LabelProviderConfig: pc}
+	ConnectorState.DeletePartialMatch(l)
+	TaskState.DeletePartialMatch(l)
+
+	ConnectorState.WithLabelValues(connector, pc, worker, state).Set(1)
+	for _, t := range tasks {
+		TaskState.WithLabelValues(connector, strconv.Itoa(t.ID), pc, t.Worker, t.State).Set(1)
+	}
+}
+
+// ForgetConnector removes all metrics recorded for a connector.
+func ForgetConnector(connector, pc string) {
+	l := prometheus.Labels{LabelConnector: connector, LabelProviderConfig: pc}
+	ConnectorState.DeletePartialMatch(l)
+	TaskState.DeletePartialMatch(l)
+	ConnectorRestarts.DeletePartialMatch(l)
+	ConnectorFailures.DeletePartialMatch(l)
+}
+
+// RecordRestart increments the restart counter of a connector.
+func RecordRestart(connector, pc string) {
+	ConnectorRestarts.WithLabelValues(connector, pc).Inc()
+}
+
+// RecordFailure increments the failure counter of a connector for the
+// supplied operation.
+func RecordFailure(connector, pc, operation string) {
+	ConnectorFailures.WithLabelValues(connector, pc, operation).Inc()
+}
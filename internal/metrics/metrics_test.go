@@ -0,0 +1,79 @@
+/*
+Copyright 2025 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package metrics
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+)
+
+func TestSetConnectorState(t *testing.T) {
+	type args struct {
+		states [][]Task
+		state  []string
+	}
+
+	cases := map[string]struct {
+		reason string
+		args   args
+		want   string
+	}{
+		"ReplacesPreviousState": {
+			reason: "Only the most recently observed connector and task states should be exported.",
+			args: args{
+				state: []string{"UNASSIGNED", "RUNNING"},
+				states: [][]Task{
+					{{ID: 0, Worker: "w1", State: "UNASSIGNED"}, {ID: 1, Worker: "w1", State: "UNASSIGNED"}},
+					{{ID: 0, Worker: "w2", State: "FAILED"}},
+				},
+			},
+			want: `
+# HELP provider_kafkaconnect_connector_state State of a Kafka Connect connector as last observed by the provider. The value is always 1.
+# TYPE provider_kafkaconnect_connector_state gauge
+provider_kafkaconnect_connector_state{connector="c",providerconfig="pc",state="RUNNING",worker="w2"} 1
+# HELP provider_kafkaconnect_connector_task_state State of a Kafka Connect connector task as last observed by the provider. The value is always 1.
+# TYPE provider_kafkaconnect_connector_task_state gauge
+provider_kafkaconnect_connector_task_state{connector="c",providerconfig="pc",state="FAILED",task="0",worker="w2"} 1
+`,
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			ConnectorState.Reset()
+			TaskState.Reset()
+
+			for i := range tc.args.state {
+				SetConnectorState("c", "pc", tc.args.states[i][0].Worker, tc.args.state[i], tc.args.states[i])
+			}
+
+			if err := testutil.CollectAndCompare(ConnectorState, strings.NewReader(tc.want), "provider_kafkaconnect_connector_state"); err != nil {
+				t.Errorf("\n%s\nSetConnectorState(...): %s", tc.reason, err)
+			}
+			if err := testutil.CollectAndCompare(TaskState, strings.NewReader(tc.want), "provider_kafkaconnect_connector_task_state"); err != nil {
+				t.Errorf("\n%s\nSetConnectorState(...): %s", tc.reason, err)
+			}
+
+			ForgetConnector("c", "pc")
+			if n := testutil.CollectAndCount(ConnectorState) + testutil.CollectAndCount(TaskState); n != 0 {
+				t.Errorf("\n%s\nForgetConnector(...): want 0 series, got %d", tc.reason, n)
+			}
+		})
+	}
+}
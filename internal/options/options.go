@@ -0,0 +1,40 @@
+/*
+Copyright 2025 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package options contains the options of the provider's controllers.
+package options
+
+import (
+	"time"
+
+	"github.com/crossplane/crossplane-runtime/pkg/controller"
+)
+
+// Options configure the provider's controllers. They extend the common
+// crossplane-runtime controller options with Kafka Connect specific ones.
+type Options struct {
+	controller.Options
+
+	// StatusPollInterval is how often the connectors of each Kafka Connect
+	// cluster are listed with their status by a shared poller. Zero disables
+	// the poller, in which case every Connector observes its connector
+	// individually.
+	StatusPollInterval time.Duration
+
+	// HealthCheckInterval is how often the Kafka Connect cluster of each
+	// ProviderConfig is checked and its status refreshed.
+	HealthCheckInterval time.Duration
+}
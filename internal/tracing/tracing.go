@@ -0,0 +1,106 @@
+/*
+Copyright 2025 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package tracing configures OpenTelemetry tracing for the provider. Until
+// Setup is called all spans are no-ops.
+package tracing
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracegrpc"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	semconv "go.opentelemetry.io/otel/semconv/v1.26.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// TracerName is the name of the tracer used by the provider.
+const TracerName = "github.com/crossplane/provider-kafkaconnect"
+
+// Span attribute keys.
+const (
+	AttributeResource       = attribute.Key("crossplane.resource.name")
+	AttributeConnector      = attribute.Key("kafkaconnect.connector")
+	AttributeProviderConfig = attribute.Key("kafkaconnect.providerconfig")
+)
+
+// Options configure how spans are exported.
+type Options struct {
+	// Endpoint of the OTLP gRPC collector, e.g. otel-collector:4317.
+	Endpoint string
+
+	// Insecure disables TLS when connecting to the collector.
+	Insecure bool
+
+	// SampleRatio is the fraction of traces that are sampled.
+	SampleRatio float64
+
+	// ServiceVersion is recorded as the service.version resource attribute.
+	ServiceVersion string
+}
+
+// Setup installs a global tracer provider that exports spans to an OTLP gRPC
+// collector, and a W3C trace context propagator. The returned function
+// flushes and shuts down the tracer provider.
+func Setup(ctx context.Context, o Options) (func(context.Context) error, error) {
+	eo := []otlptracegrpc.Option{otlptracegrpc.WithEndpoint(o.Endpoint)}
+	if o.Insecure {
+		eo = append(eo, otlptracegrpc.WithInsecure())
+	}
+	exp, err := otlptracegrpc.New(ctx, eo...)
+	if err != nil {
+		return nil, errors.Wrap(err, "cannot create OTLP trace exporter")
+	}
+
+	return Install(exp, o).Shutdown, nil
+}
+
+// Install installs a global tracer provider that exports spans with the
+// supplied exporter, and a W3C trace context propagator. It is used by Setup
+// and by tests, which may supply an in-memory exporter.
+func Install(exp sdktrace.SpanExporter, o Options) *sdktrace.TracerProvider {
+	tp := sdktrace.NewTracerProvider(
+		sdktrace.WithBatcher(exp),
+		sdktrace.WithSampler(sdktrace.ParentBased(sdktrace.TraceIDRatioBased(o.SampleRatio))),
+		sdktrace.WithResource(resource.NewSchemaless(
+			semconv.ServiceName("provider-kafkaconnect"),
+			semconv.ServiceVersion(o.ServiceVersion),
+		)),
+	)
+	otel.SetTracerProvider(tp)
+	otel.SetTextMapPropagator(propagation.NewCompositeTextMapPropagator(propagation.TraceContext{}, propagation.Baggage{}))
+	return tp
+}
+
+// Start a span with the supplied name using the provider's tracer.
+func Start(ctx context.Context, name string, opts ...trace.SpanStartOption) (context.Context, trace.Span) {
+	return otel.Tracer(TracerName).Start(ctx, name, opts...)
+}
+
+// End the supplied span, recording the supplied error if it is not nil.
+func End(span trace.Span, err error) {
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	span.End()
+}